@@ -55,6 +55,9 @@ func Analyze(fileName string, opts *AnalyzeOpts, readOpts *ReadOpts) error {
 	if rOpts.InJQuery != "" {
 		tableName = fileName + "::" + rOpts.InJQuery
 	}
+	if rOpts.InSelector != "" {
+		tableName = fileName + "::" + rOpts.InSelector
+	}
 
 	defer func() {
 		if deferr := file.Close(); deferr != nil {
@@ -0,0 +1,367 @@
+package trdsql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/array"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+)
+
+// arrowDBType returns the database type corresponding to the Arrow data type.
+// Types that have no common database type return the DefaultDBType.
+func arrowDBType(dt arrow.DataType) string {
+	switch dt.ID() {
+	case arrow.BOOL:
+		return "bool"
+	case arrow.INT8, arrow.INT16, arrow.INT32, arrow.UINT8, arrow.UINT16:
+		return "int"
+	case arrow.INT64, arrow.UINT32:
+		return "bigint"
+	case arrow.UINT64, arrow.DECIMAL32, arrow.DECIMAL64, arrow.DECIMAL128, arrow.DECIMAL256:
+		return "numeric"
+	case arrow.FLOAT16, arrow.FLOAT32, arrow.FLOAT64:
+		return "double precision"
+	case arrow.DATE32, arrow.DATE64:
+		return "date"
+	case arrow.TIMESTAMP:
+		return "timestamp"
+	case arrow.DICTIONARY:
+		return arrowDBType(dt.(*arrow.DictionaryType).ValueType)
+	default:
+		return DefaultDBType
+	}
+}
+
+// arrowValue returns the i-th value of the Arrow array
+// as a value that can be passed to the database.
+// Nested types (list, struct, map) are returned as JSON strings.
+func arrowValue(arr arrow.Array, i int) any {
+	if arr.IsNull(i) {
+		return nil
+	}
+	switch a := arr.(type) {
+	case *array.Boolean:
+		return a.Value(i)
+	case *array.Int8:
+		return int64(a.Value(i))
+	case *array.Int16:
+		return int64(a.Value(i))
+	case *array.Int32:
+		return int64(a.Value(i))
+	case *array.Int64:
+		return a.Value(i)
+	case *array.Uint8:
+		return int64(a.Value(i))
+	case *array.Uint16:
+		return int64(a.Value(i))
+	case *array.Uint32:
+		return int64(a.Value(i))
+	case *array.Uint64:
+		v := a.Value(i)
+		if v > math.MaxInt64 {
+			return strconv.FormatUint(v, 10)
+		}
+		return int64(v)
+	case *array.Float16:
+		return float64(a.Value(i).Float32())
+	case *array.Float32:
+		return float64(a.Value(i))
+	case *array.Float64:
+		return a.Value(i)
+	case *array.String:
+		return strings.Clone(a.Value(i))
+	case *array.LargeString:
+		return strings.Clone(a.Value(i))
+	case *array.Date32:
+		return a.Value(i).FormattedString()
+	case *array.Date64:
+		return a.Value(i).FormattedString()
+	case *array.Timestamp:
+		toTime, err := a.DataType().(*arrow.TimestampType).GetToTimeFunc()
+		if err != nil {
+			return a.ValueStr(i)
+		}
+		return toTime(a.Value(i))
+	case *array.Dictionary:
+		return arrowValue(a.Dictionary(), a.GetValueIndex(i))
+	case array.ListLike, *array.Struct:
+		b, err := json.Marshal(a.GetOneForMarshal(i))
+		if err != nil {
+			log.Printf("ERROR: arrowValue:%s", err)
+			return a.ValueStr(i)
+		}
+		return string(b)
+	default:
+		return a.ValueStr(i)
+	}
+}
+
+// dbArrowType returns the Arrow data type corresponding to the database type name.
+// It returns nil if the database type is unknown.
+func dbArrowType(dbType string) arrow.DataType {
+	switch dbTypeKind(dbType) {
+	case typeInt, typeBigint:
+		return arrow.PrimitiveTypes.Int64
+	case typeFloat:
+		return arrow.PrimitiveTypes.Float64
+	case typeBool:
+		return arrow.FixedWidthTypes.Boolean
+	case typeDate:
+		return arrow.FixedWidthTypes.Date32
+	case typeTimestamp:
+		return arrow.FixedWidthTypes.Timestamp_us
+	case typeBinary:
+		return arrow.BinaryTypes.Binary
+	case typeUnknown:
+		return nil
+	default:
+		// text, numeric(decimal) and others are strings.
+		return arrow.BinaryTypes.String
+	}
+}
+
+// valueArrowType returns the Arrow data type inferred from the value.
+// It is used when the database does not return the type name
+// (e.g. the result of an expression in SQLite).
+func valueArrowType(v any) arrow.DataType {
+	switch v.(type) {
+	case int, int32, int64:
+		return arrow.PrimitiveTypes.Int64
+	case float32, float64:
+		return arrow.PrimitiveTypes.Float64
+	case bool:
+		return arrow.FixedWidthTypes.Boolean
+	case time.Time:
+		return arrow.FixedWidthTypes.Timestamp_us
+	default:
+		return arrow.BinaryTypes.String
+	}
+}
+
+// appendArrowValue appends a database value to the Arrow builder.
+func appendArrowValue(b array.Builder, v any) error {
+	if v == nil {
+		b.AppendNull()
+		return nil
+	}
+	switch b := b.(type) {
+	case *array.Int64Builder:
+		switch t := v.(type) {
+		case int64:
+			b.Append(t)
+		case int:
+			b.Append(int64(t))
+		case int32:
+			b.Append(int64(t))
+		case bool:
+			if t {
+				b.Append(1)
+			} else {
+				b.Append(0)
+			}
+		default:
+			i, err := strconv.ParseInt(ValString(v), 10, 64)
+			if err != nil {
+				return fmt.Errorf("%w: %v to integer", ErrUnableConvert, v)
+			}
+			b.Append(i)
+		}
+	case *array.Float64Builder:
+		switch t := v.(type) {
+		case float64:
+			b.Append(t)
+		case float32:
+			b.Append(float64(t))
+		case int64:
+			b.Append(float64(t))
+		default:
+			f, err := strconv.ParseFloat(ValString(v), 64)
+			if err != nil {
+				return fmt.Errorf("%w: %v to float", ErrUnableConvert, v)
+			}
+			b.Append(f)
+		}
+	case *array.BooleanBuilder:
+		switch t := v.(type) {
+		case bool:
+			b.Append(t)
+		case int64:
+			b.Append(t != 0)
+		default:
+			f, err := strconv.ParseBool(ValString(v))
+			if err != nil {
+				return fmt.Errorf("%w: %v to bool", ErrUnableConvert, v)
+			}
+			b.Append(f)
+		}
+	case *array.Date32Builder:
+		t, err := valueTime(v)
+		if err != nil {
+			return err
+		}
+		b.Append(arrow.Date32FromTime(t))
+	case *array.TimestampBuilder:
+		t, err := valueTime(v)
+		if err != nil {
+			return err
+		}
+		ts, err := arrow.TimestampFromTime(t, arrow.Microsecond)
+		if err != nil {
+			return err
+		}
+		b.Append(ts)
+	case *array.BinaryBuilder:
+		switch t := v.(type) {
+		case []byte:
+			b.Append(t)
+		default:
+			b.AppendString(ValString(v))
+		}
+	case *array.StringBuilder:
+		b.Append(ValString(v))
+	default:
+		return fmt.Errorf("%w: %s", ErrUnableConvert, b.Type())
+	}
+	return nil
+}
+
+// valueTime returns the time of the database value.
+func valueTime(v any) (time.Time, error) {
+	if t, ok := v.(time.Time); ok {
+		return t, nil
+	}
+	t, err := parseTime(ValString(v))
+	if err != nil {
+		return t, fmt.Errorf("%w: %v to time", ErrUnableConvert, v)
+	}
+	return t, nil
+}
+
+// arrowBatch builds Arrow record batches from the rows of the query result.
+type arrowBatch struct {
+	builder *array.RecordBuilder
+	schema  *arrow.Schema
+	columns []string
+	types   []arrow.DataType
+	size    int
+	count   int
+}
+
+// newArrowBatch returns an arrowBatch that makes a record batch every size rows.
+func newArrowBatch(columns []string, types []string, size int) *arrowBatch {
+	b := &arrowBatch{
+		columns: columns,
+		types:   make([]arrow.DataType, len(columns)),
+		size:    size,
+	}
+	for i := range columns {
+		if i < len(types) {
+			b.types[i] = dbArrowType(types[i])
+		}
+	}
+	return b
+}
+
+// init determines the schema.
+// Columns of unknown type are inferred from the values of the first row.
+func (b *arrowBatch) init(values []any) *arrow.Schema {
+	fields := make([]arrow.Field, len(b.columns))
+	for i, name := range b.columns {
+		dt := b.types[i]
+		if dt == nil {
+			if i < len(values) && values[i] != nil {
+				dt = valueArrowType(values[i])
+			} else {
+				dt = arrow.BinaryTypes.String
+			}
+		}
+		fields[i] = arrow.Field{Name: name, Type: dt, Nullable: true}
+	}
+	b.schema = arrow.NewSchema(fields, nil)
+	b.builder = array.NewRecordBuilder(memory.DefaultAllocator, b.schema)
+	return b.schema
+}
+
+// append appends a row.
+func (b *arrowBatch) append(values []any) error {
+	for i, v := range values {
+		if err := appendArrowValue(b.builder.Field(i), v); err != nil {
+			return fmt.Errorf("column %s: %w", b.columns[i], err)
+		}
+	}
+	b.count++
+	return nil
+}
+
+// full returns true if the number of rows has reached the batch size.
+func (b *arrowBatch) full() bool {
+	return b.count >= b.size
+}
+
+// newRecord returns a record batch of the appended rows and resets the count.
+// It returns nil if there are no rows.
+func (b *arrowBatch) newRecord() arrow.RecordBatch {
+	if b.count == 0 {
+		return nil
+	}
+	b.count = 0
+	return b.builder.NewRecordBatch()
+}
+
+// release releases the builder.
+func (b *arrowBatch) release() {
+	if b.builder != nil {
+		b.builder.Release()
+	}
+}
+
+// arrowRecords is an iterator of Arrow record batches.
+type arrowRecords interface {
+	Next() bool
+	RecordBatch() arrow.RecordBatch
+	Err() error
+	Release()
+}
+
+// arrowRows reads rows from Arrow record batches.
+type arrowRows struct {
+	records arrowRecords
+	record  arrow.RecordBatch
+	pos     int
+}
+
+// read reads one row from the current record batch,
+// and reads the next record batch when it is exhausted.
+func (a *arrowRows) read(row []any) ([]any, error) {
+	for a.record == nil || a.pos >= int(a.record.NumRows()) {
+		if a.records == nil {
+			return row, io.EOF
+		}
+		if !a.records.Next() {
+			err := a.records.Err()
+			a.records.Release()
+			a.records = nil
+			a.record = nil
+			if err != nil && !errors.Is(err, io.EOF) {
+				return row, err
+			}
+			return row, io.EOF
+		}
+		a.record = a.records.RecordBatch()
+		a.pos = 0
+	}
+	for i := 0; i < len(row) && i < int(a.record.NumCols()); i++ {
+		row[i] = arrowValue(a.record.Column(i), a.pos)
+	}
+	a.pos++
+	return row, nil
+}
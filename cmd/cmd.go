@@ -83,6 +83,9 @@ func (cli Cli) Run(args []string) int {
 		inLimitRead int
 		inNull      nilString
 		inRowNumber bool
+		inRegex     string
+		inRegexRej  bool
+		inWidths    string
 
 		outFlag         outputFlag
 		outFile         string
@@ -94,7 +97,26 @@ func (cli Cli) Run(args []string) int {
 		outUseCRLF      bool
 		outHeader       bool
 		outNoWrap       bool
+		outStreamRows   int
+		outMaxWidth     int
 		outNull         nilString
+		outCodec        string
+		outXMLRoot      string
+		outXMLRow       string
+		outXMLAttr      bool
+		outFixedWidths  string
+		outFixedAlign   string
+		outFixedPad     string
+		outFixedOver    string
+		outHTMLDocument bool
+		outSQLTable     string
+		outSQLDialect   string
+		outSQLCreate    bool
+		outSQLBatch     int
+		outSQLUpsert    string
+		outRSTStyle     string
+		outTemplate     string
+		outTemplateStr  string
 	)
 
 	flags := flag.NewFlagSet(trdsql.AppName, flag.ExitOnError)
@@ -125,6 +147,7 @@ func (cli Cli) Run(args []string) int {
 	flags.StringVar(&inJQuery, "ijq", "", "jq expression string for input(JSON/JSONL only).")
 	flags.Var(&inNull, "inull", "value(string) to convert to null on input.")
 	flags.BoolVar(&inRowNumber, "inum", false, "add row number column.")
+	flags.BoolVar(&inRegexRej, "iregex-reject", false, "store unmatched lines in the _reject column(regex only).")
 
 	flags.BoolVar(&inFlag.CSV, "icsv", false, "CSV format for input.")
 	flags.BoolVar(&inFlag.LTSV, "iltsv", false, "LTSV format for input.")
@@ -133,6 +156,25 @@ func (cli Cli) Run(args []string) int {
 	flags.BoolVar(&inFlag.TBLN, "itbln", false, "TBLN format for input.")
 	flags.BoolVar(&inFlag.WIDTH, "iwidth", false, "width specification format for input.")
 	flags.BoolVar(&inFlag.TEXT, "itext", false, "text format for input.")
+	flags.BoolVar(&inFlag.PARQUET, "iparquet", false, "Parquet format for input.")
+	flags.BoolVar(&inFlag.XLSX, "ixlsx", false, "XLSX format for input.")
+	flags.BoolVar(&inFlag.ARROW, "iarrow", false, "Arrow IPC(Feather) format for input.")
+	flags.BoolVar(&inFlag.AVRO, "iavro", false, "Avro format for input.")
+	flags.BoolVar(&inFlag.XML, "ixml", false, "XML format for input.")
+	flags.BoolVar(&inFlag.MD, "imd", false, "Markdown table format for input.")
+	flags.BoolVar(&inFlag.AT, "iat", false, "ASCII Table format for input.")
+	flags.BoolVar(&inFlag.VF, "ivf", false, "Vertical format for input.")
+	flags.BoolVar(&inFlag.SQLITE, "isqlite", false, "SQLite database file for input.")
+	flags.BoolVar(&inFlag.ACCESSLOG, "iaccesslog", false, "Apache/Nginx access log(common and combined) for input.")
+	flags.BoolVar(&inFlag.W3C, "iw3c", false, "W3C extended log format for input.")
+	flags.BoolVar(&inFlag.SYSLOG, "isyslog", false, "syslog(RFC 3164 and RFC 5424) for input.")
+	flags.BoolVar(&inFlag.LOGFMT, "ilogfmt", false, "logfmt format for input.")
+	flags.BoolVar(&inFlag.TOML, "itoml", false, "TOML format for input.")
+	flags.BoolVar(&inFlag.INI, "iini", false, "INI format for input.")
+	flags.BoolVar(&inFlag.ENV, "ienv", false, "dotenv(.env) format for input.")
+	flags.BoolVar(&inFlag.HTML, "ihtml", false, "HTML table format for input.")
+	flags.StringVar(&inRegex, "iregex", "", "regular expression with named groups(or grok patterns) for input.")
+	flags.StringVar(&inWidths, "iwidths", "", "column positions of the fixed-width input(e.g. 1-8,9-20,21- or id:8,name:12).")
 
 	flags.StringVar(&outFile, "out", "", "output file name.")
 	flags.BoolVar(&outWithoutGuess, "out-without-guess", false, "output without guessing (when using -out).")
@@ -141,9 +183,26 @@ func (cli Cli) Run(args []string) int {
 	flags.BoolVar(&outAllQuotes, "oaq", false, "enclose all fields in quotes for output.")
 	flags.BoolVar(&outUseCRLF, "ocrlf", false, "use CRLF for output. End each output line with '\\r\\n' instead of '\\n'.")
 	flags.BoolVar(&outNoWrap, "onowrap", false, "do not wrap long lines(at/md only).")
+	flags.IntVar(&outStreamRows, "ostream-rows", 0, "number of rows to size the columns, and write rows as they arrive(at/md only).")
+	flags.IntVar(&outMaxWidth, "omax-width", 0, "maximum width of the columns, and write rows as they arrive(at/md only).")
 	flags.BoolVar(&outHeader, "oh", false, "output column name as header.")
 	flags.StringVar(&outCompression, "oz", "", "output compression format. [ gz | bz2 | zstd | lz4 | xz ]")
 	flags.Var(&outNull, "onull", "value(string) to convert from null on output.")
+	flags.StringVar(&outCodec, "ocodec", "", "compression codec inside the file(parquet, arrow and avro). [ snappy | gzip | zstd | lz4 | brotli | deflate | none ]")
+	flags.StringVar(&outXMLRoot, "oxml-root", "rows", "name of the root element(xml).")
+	flags.StringVar(&outXMLRow, "oxml-row", "row", "name of the row element(xml).")
+	flags.BoolVar(&outXMLAttr, "oxml-attr", false, "output columns as attributes of the row element(xml).")
+	flags.StringVar(&outFixedWidths, "ofixed-widths", "", "column widths(fixed). e.g. 8,12R,* ('*' buffers all rows to compute the width) (default computed from the data)")
+	flags.StringVar(&outFixedAlign, "ofixed-align", "left", "alignment of the columns(fixed). [ left | right | auto ]")
+	flags.StringVar(&outFixedPad, "ofixed-pad", " ", "padding character(fixed).")
+	flags.StringVar(&outFixedOver, "ofixed-overflow", "auto", "policy for values that exceed the width(fixed). [ auto | truncate | error ]")
+	flags.BoolVar(&outHTMLDocument, "ohtml-document", false, "output a full HTML document instead of a table(html).")
+	flags.StringVar(&outSQLTable, "osql-table", "result", "name of the target table(sql).")
+	flags.StringVar(&outSQLDialect, "osql-dialect", "", "dialect of the statements(sql). [ sqlite | mysql | postgres ] (default the driver)")
+	flags.BoolVar(&outSQLCreate, "osql-create", false, "output CREATE TABLE before INSERT(sql).")
+	flags.IntVar(&outSQLBatch, "osql-batch", 100, "number of rows in one INSERT statement(sql).")
+	flags.StringVar(&outSQLUpsert, "osql-upsert", "", "key columns of the upsert(sql). e.g. id,name")
+	flags.StringVar(&outRSTStyle, "orst-style", "grid", "style of the table(rst). [ grid | simple ]")
 
 	flags.BoolVar(&outFlag.CSV, "ocsv", false, "CSV format for output.")
 	flags.BoolVar(&outFlag.LTSV, "oltsv", false, "LTSV format for output.")
@@ -156,6 +215,21 @@ func (cli Cli) Run(args []string) int {
 	flags.BoolVar(&outFlag.JSONL, "ojsonl", false, "JSON lines format for output.")
 	flags.BoolVar(&outFlag.YAML, "oyaml", false, "YAML format for output.")
 	flags.BoolVar(&outFlag.TSV, "otsv", false, "TSV format for output.")
+	flags.BoolVar(&outFlag.PARQUET, "oparquet", false, "Parquet format for output.")
+	flags.BoolVar(&outFlag.XLSX, "oxlsx", false, "XLSX format for output.")
+	flags.BoolVar(&outFlag.ARROW, "oarrow", false, "Arrow IPC(Feather) format for output.")
+	flags.BoolVar(&outFlag.AVRO, "oavro", false, "Avro format for output.")
+	flags.BoolVar(&outFlag.XML, "oxml", false, "XML format for output.")
+	flags.BoolVar(&outFlag.LOGFMT, "ologfmt", false, "logfmt format for output.")
+	flags.BoolVar(&outFlag.FIXED, "ofixed", false, "Fixed-width format for output.")
+	flags.BoolVar(&outFlag.HTML, "ohtml", false, "HTML table format for output.")
+	flags.BoolVar(&outFlag.SQL, "osql", false, "SQL INSERT statements for output.")
+	flags.BoolVar(&outFlag.ADOC, "oadoc", false, "AsciiDoc table format for output.")
+	flags.BoolVar(&outFlag.RST, "orst", false, "reStructuredText table format for output.")
+	flags.BoolVar(&outFlag.LATEX, "olatex", false, "LaTeX tabular format for output.")
+	flags.BoolVar(&outFlag.ORG, "oorg", false, "Org-mode table format for output.")
+	flags.StringVar(&outTemplate, "otemplate", "", "Go text/template file for output.")
+	flags.StringVar(&outTemplateStr, "otemplate-string", "", "Go text/template string for output.")
 
 	if err := flags.Parse(args[1:]); err != nil {
 		log.Printf("ERROR: %s", err)
@@ -171,6 +245,12 @@ func (cli Cli) Run(args []string) int {
 		trdsql.EnableDebug()
 	}
 
+	inFlag.REGEX = inRegex != ""
+	outFlag.TEMPLATE = outTemplate != "" || outTemplateStr != ""
+	if inWidths != "" {
+		inFlag.WIDTH = true
+	}
+
 	// MultipleQueries is enabled by default.
 	trdsql.EnableMultipleQueries()
 
@@ -207,6 +287,9 @@ func (cli Cli) Run(args []string) int {
 			trdsql.InSkip(inSkip),
 			trdsql.InPreRead(inPreRead),
 			trdsql.InJQ(inJQuery),
+			trdsql.InRegex(inRegex),
+			trdsql.InRegexReject(inRegexRej),
+			trdsql.InWidths(inWidths),
 		)
 		if err = trdsql.Analyze(analyze, opts, readOpts); err != nil {
 			log.Printf("ERROR: %s", err)
@@ -251,6 +334,9 @@ func (cli Cli) Run(args []string) int {
 		trdsql.InNeedNULL(inNull.valid),
 		trdsql.InNULL(inNull.str),
 		trdsql.InRowNumber(inRowNumber),
+		trdsql.InRegex(inRegex),
+		trdsql.InRegexReject(inRegexRej),
+		trdsql.InWidths(inWidths),
 	)
 
 	writer := cli.OutStream
@@ -289,8 +375,27 @@ func (cli Cli) Run(args []string) int {
 		trdsql.OutUseCRLF(outUseCRLF),
 		trdsql.OutHeader(outHeader),
 		trdsql.OutNoWrap(outNoWrap),
+		trdsql.OutStreamRows(outStreamRows),
+		trdsql.OutMaxWidth(outMaxWidth),
 		trdsql.OutNeedNULL(outNull.valid),
 		trdsql.OutNULL(outNull.str),
+		trdsql.OutCodec(outCodec),
+		trdsql.OutXMLRoot(outXMLRoot),
+		trdsql.OutXMLRow(outXMLRow),
+		trdsql.OutXMLAttr(outXMLAttr),
+		trdsql.OutFixedWidths(outFixedWidths),
+		trdsql.OutFixedAlign(outFixedAlign),
+		trdsql.OutFixedPad(outFixedPad),
+		trdsql.OutFixedOverflow(outFixedOver),
+		trdsql.OutHTMLDocument(outHTMLDocument),
+		trdsql.OutSQLTable(outSQLTable),
+		trdsql.OutSQLDialect(sqlDialect(outSQLDialect, driver)),
+		trdsql.OutSQLCreate(outSQLCreate),
+		trdsql.OutSQLBatch(outSQLBatch),
+		trdsql.OutSQLUpsert(outSQLUpsert),
+		trdsql.OutRSTStyle(outRSTStyle),
+		trdsql.OutTemplate(outTemplate),
+		trdsql.OutTemplateString(outTemplateStr),
 		trdsql.OutStream(writer),
 		trdsql.ErrStream(cli.ErrStream),
 	)
@@ -401,6 +506,20 @@ func printDBList(w io.Writer, cfg *config) {
 	}
 }
 
+// sqlDialect returns the dialect of the SQL output.
+// If it is not specified, the dialect of the driver is used.
+func sqlDialect(dialect string, driver string) string {
+	if dialect != "" {
+		return dialect
+	}
+	switch driver {
+	case "mysql", "postgres":
+		return driver
+	default:
+		return "sqlite"
+	}
+}
+
 func quoteOpts(opts *trdsql.AnalyzeOpts, driver string) *trdsql.AnalyzeOpts {
 	if driver == "postgres" {
 		opts.Quote = `\"`
@@ -498,13 +617,31 @@ func quotedArg(arg string) string {
 
 // inputFlag represents the format of the input.
 type inputFlag struct {
-	CSV   bool
-	LTSV  bool
-	JSON  bool
-	YAML  bool
-	TBLN  bool
-	WIDTH bool
-	TEXT  bool
+	CSV       bool
+	LTSV      bool
+	JSON      bool
+	YAML      bool
+	TBLN      bool
+	WIDTH     bool
+	TEXT      bool
+	PARQUET   bool
+	XLSX      bool
+	ARROW     bool
+	AVRO      bool
+	XML       bool
+	MD        bool
+	AT        bool
+	VF        bool
+	SQLITE    bool
+	REGEX     bool
+	ACCESSLOG bool
+	W3C       bool
+	SYSLOG    bool
+	LOGFMT    bool
+	TOML      bool
+	INI       bool
+	ENV       bool
+	HTML      bool
 }
 
 // inputFormat returns format from flag.
@@ -524,6 +661,42 @@ func inputFormat(i inputFlag) trdsql.Format {
 		return trdsql.WIDTH
 	case i.TEXT:
 		return trdsql.TEXT
+	case i.PARQUET:
+		return trdsql.PARQUET
+	case i.XLSX:
+		return trdsql.XLSX
+	case i.ARROW:
+		return trdsql.ARROW
+	case i.AVRO:
+		return trdsql.AVRO
+	case i.XML:
+		return trdsql.XML
+	case i.MD:
+		return trdsql.MD
+	case i.AT:
+		return trdsql.AT
+	case i.VF:
+		return trdsql.VF
+	case i.SQLITE:
+		return trdsql.SQLITE
+	case i.REGEX:
+		return trdsql.REGEX
+	case i.ACCESSLOG:
+		return trdsql.ACCESSLOG
+	case i.W3C:
+		return trdsql.W3C
+	case i.SYSLOG:
+		return trdsql.SYSLOG
+	case i.LOGFMT:
+		return trdsql.LOGFMT
+	case i.TOML:
+		return trdsql.TOML
+	case i.INI:
+		return trdsql.INI
+	case i.ENV:
+		return trdsql.ENV
+	case i.HTML:
+		return trdsql.HTML
 	default:
 		return trdsql.GUESS
 	}
@@ -531,7 +704,7 @@ func inputFormat(i inputFlag) trdsql.Format {
 
 func isInFormat(name string) bool {
 	switch name {
-	case "ig", "icsv", "iltsv", "ijson", "iyaml", "itbln", "iwidth", "itext":
+	case "ig", "icsv", "iltsv", "ijson", "iyaml", "itbln", "iwidth", "itext", "iparquet", "ixlsx", "iarrow", "iavro", "ixml", "imd", "iat", "ivf", "isqlite", "iregex", "iaccesslog", "iw3c", "isyslog", "ilogfmt", "itoml", "iini", "ienv", "ihtml":
 		return true
 	}
 	return false
@@ -539,17 +712,31 @@ func isInFormat(name string) bool {
 
 // outputFlag represents the format of the output.
 type outputFlag struct {
-	CSV   bool
-	LTSV  bool
-	JSON  bool
-	JSONL bool
-	YAML  bool
-	TBLN  bool
-	AT    bool
-	MD    bool
-	VF    bool
-	RAW   bool
-	TSV   bool
+	CSV      bool
+	LTSV     bool
+	JSON     bool
+	JSONL    bool
+	YAML     bool
+	TBLN     bool
+	AT       bool
+	MD       bool
+	VF       bool
+	RAW      bool
+	TSV      bool
+	PARQUET  bool
+	XLSX     bool
+	ARROW    bool
+	AVRO     bool
+	XML      bool
+	LOGFMT   bool
+	FIXED    bool
+	HTML     bool
+	SQL      bool
+	ADOC     bool
+	RST      bool
+	LATEX    bool
+	ORG      bool
+	TEMPLATE bool
 }
 
 // outFormat returns format from flag.
@@ -575,6 +762,34 @@ func outputFormat(o outputFlag) trdsql.Format {
 		return trdsql.JSONL
 	case o.YAML:
 		return trdsql.YAML
+	case o.PARQUET:
+		return trdsql.PARQUET
+	case o.XLSX:
+		return trdsql.XLSX
+	case o.ARROW:
+		return trdsql.ARROW
+	case o.AVRO:
+		return trdsql.AVRO
+	case o.XML:
+		return trdsql.XML
+	case o.LOGFMT:
+		return trdsql.LOGFMT
+	case o.FIXED:
+		return trdsql.FIXED
+	case o.HTML:
+		return trdsql.HTML
+	case o.SQL:
+		return trdsql.SQL
+	case o.ADOC:
+		return trdsql.ADOC
+	case o.RST:
+		return trdsql.RST
+	case o.LATEX:
+		return trdsql.LATEX
+	case o.ORG:
+		return trdsql.ORG
+	case o.TEMPLATE:
+		return trdsql.TEMPLATE
 	case o.CSV:
 		return trdsql.CSV
 	default:
@@ -584,7 +799,7 @@ func outputFormat(o outputFlag) trdsql.Format {
 
 func isOutFormat(name string) bool {
 	switch name {
-	case "ocsv", "oltsv", "ojson", "ojsonl", "oyaml", "otbln", "oat", "omd", "ovf", "oraw", "otsv":
+	case "ocsv", "oltsv", "ojson", "ojsonl", "oyaml", "otbln", "oat", "omd", "ovf", "oraw", "otsv", "oparquet", "oxlsx", "oarrow", "oavro", "oxml", "ologfmt", "ofixed", "ohtml", "osql", "oadoc", "orst", "olatex", "oorg", "otemplate", "otemplate-string":
 		return true
 	}
 	return false
@@ -58,6 +58,168 @@ func Test_inputFormat(t *testing.T) {
 			},
 			want: trdsql.TBLN,
 		},
+		{
+			name: "testPARQUET",
+			args: args{
+				i: inputFlag{
+					PARQUET: true,
+				},
+			},
+			want: trdsql.PARQUET,
+		},
+		{
+			name: "testXLSX",
+			args: args{
+				i: inputFlag{
+					XLSX: true,
+				},
+			},
+			want: trdsql.XLSX,
+		},
+		{
+			name: "testARROW",
+			args: args{
+				i: inputFlag{
+					ARROW: true,
+				},
+			},
+			want: trdsql.ARROW,
+		},
+		{
+			name: "testAVRO",
+			args: args{
+				i: inputFlag{
+					AVRO: true,
+				},
+			},
+			want: trdsql.AVRO,
+		},
+		{
+			name: "testXML",
+			args: args{
+				i: inputFlag{
+					XML: true,
+				},
+			},
+			want: trdsql.XML,
+		},
+		{
+			name: "testMD",
+			args: args{
+				i: inputFlag{
+					MD: true,
+				},
+			},
+			want: trdsql.MD,
+		},
+		{
+			name: "testAT",
+			args: args{
+				i: inputFlag{
+					AT: true,
+				},
+			},
+			want: trdsql.AT,
+		},
+		{
+			name: "testVF",
+			args: args{
+				i: inputFlag{
+					VF: true,
+				},
+			},
+			want: trdsql.VF,
+		},
+		{
+			name: "testSQLITE",
+			args: args{
+				i: inputFlag{
+					SQLITE: true,
+				},
+			},
+			want: trdsql.SQLITE,
+		},
+		{
+			name: "testREGEX",
+			args: args{
+				i: inputFlag{
+					REGEX: true,
+				},
+			},
+			want: trdsql.REGEX,
+		},
+		{
+			name: "testACCESSLOG",
+			args: args{
+				i: inputFlag{
+					ACCESSLOG: true,
+				},
+			},
+			want: trdsql.ACCESSLOG,
+		},
+		{
+			name: "testW3C",
+			args: args{
+				i: inputFlag{
+					W3C: true,
+				},
+			},
+			want: trdsql.W3C,
+		},
+		{
+			name: "testSYSLOG",
+			args: args{
+				i: inputFlag{
+					SYSLOG: true,
+				},
+			},
+			want: trdsql.SYSLOG,
+		},
+		{
+			name: "testLOGFMT",
+			args: args{
+				i: inputFlag{
+					LOGFMT: true,
+				},
+			},
+			want: trdsql.LOGFMT,
+		},
+		{
+			name: "testTOML",
+			args: args{
+				i: inputFlag{
+					TOML: true,
+				},
+			},
+			want: trdsql.TOML,
+		},
+		{
+			name: "testINI",
+			args: args{
+				i: inputFlag{
+					INI: true,
+				},
+			},
+			want: trdsql.INI,
+		},
+		{
+			name: "testENV",
+			args: args{
+				i: inputFlag{
+					ENV: true,
+				},
+			},
+			want: trdsql.ENV,
+		},
+		{
+			name: "testHTML",
+			args: args{
+				i: inputFlag{
+					HTML: true,
+				},
+			},
+			want: trdsql.HTML,
+		},
 		{
 			name: "testGUESS",
 			args: args{
@@ -178,6 +340,132 @@ func Test_outputFormat(t *testing.T) {
 			},
 			want: trdsql.TBLN,
 		},
+		{
+			name: "testPARQUET",
+			args: args{
+				o: outputFlag{
+					PARQUET: true,
+				},
+			},
+			want: trdsql.PARQUET,
+		},
+		{
+			name: "testXLSX",
+			args: args{
+				o: outputFlag{
+					XLSX: true,
+				},
+			},
+			want: trdsql.XLSX,
+		},
+		{
+			name: "testARROW",
+			args: args{
+				o: outputFlag{
+					ARROW: true,
+				},
+			},
+			want: trdsql.ARROW,
+		},
+		{
+			name: "testAVRO",
+			args: args{
+				o: outputFlag{
+					AVRO: true,
+				},
+			},
+			want: trdsql.AVRO,
+		},
+		{
+			name: "testXML",
+			args: args{
+				o: outputFlag{
+					XML: true,
+				},
+			},
+			want: trdsql.XML,
+		},
+		{
+			name: "testLOGFMT",
+			args: args{
+				o: outputFlag{
+					LOGFMT: true,
+				},
+			},
+			want: trdsql.LOGFMT,
+		},
+		{
+			name: "testFIXED",
+			args: args{
+				o: outputFlag{
+					FIXED: true,
+				},
+			},
+			want: trdsql.FIXED,
+		},
+		{
+			name: "testHTML",
+			args: args{
+				o: outputFlag{
+					HTML: true,
+				},
+			},
+			want: trdsql.HTML,
+		},
+		{
+			name: "testSQL",
+			args: args{
+				o: outputFlag{
+					SQL: true,
+				},
+			},
+			want: trdsql.SQL,
+		},
+		{
+			name: "testADOC",
+			args: args{
+				o: outputFlag{
+					ADOC: true,
+				},
+			},
+			want: trdsql.ADOC,
+		},
+		{
+			name: "testRST",
+			args: args{
+				o: outputFlag{
+					RST: true,
+				},
+			},
+			want: trdsql.RST,
+		},
+		{
+			name: "testLATEX",
+			args: args{
+				o: outputFlag{
+					LATEX: true,
+				},
+			},
+			want: trdsql.LATEX,
+		},
+		{
+			name: "testORG",
+			args: args{
+				o: outputFlag{
+					ORG: true,
+				},
+			},
+			want: trdsql.ORG,
+		},
+		{
+			name: "testTEMPLATE",
+			args: args{
+				o: outputFlag{
+					TEMPLATE: true,
+				},
+			},
+			want: trdsql.TEMPLATE,
+		},
 		{
 			name: "testDEFAULT",
 			args: args{
@@ -566,6 +854,51 @@ func TestCli_Run_Out(t *testing.T) {
 	}
 }
 
+func TestCli_Run_iwidths(t *testing.T) {
+	fixed, err := os.ReadFile(filepath.Join("..", "testdata", "fixed.txt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The extension is csv, but -iwidths forces the fixed-width input.
+	fileName := filepath.Join(t.TempDir(), "fixed.csv")
+	if err := os.WriteFile(fileName, fixed, 0o644); err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		args []string
+		want string
+	}{
+		{
+			name: "positions",
+			args: []string{"trdsql", "-iwidths", "1-8,9-20,21-", "-ocsv", "SELECT * FROM " + fileName},
+			want: "00000001,Orange,50\n00000002,メロン,500\n00000003,Apple,100\n",
+		},
+		{
+			name: "names",
+			args: []string{"trdsql", "-iwidths", "id:8,name:12,price:3", "-ocsv", "-oh", "SELECT id, price FROM " + fileName},
+			want: "id,price\n00000001,50\n00000002,500\n00000003,100\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			outStream, errStream := new(bytes.Buffer), new(bytes.Buffer)
+			cli := Cli{
+				OutStream: outStream,
+				ErrStream: errStream,
+			}
+			var buf bytes.Buffer
+			log.SetOutput(&buf)
+			if got := cli.Run(tt.args); got != 0 {
+				t.Fatalf("Run() = %v, want 0: %s", got, buf.String())
+			}
+			if got := outStream.String(); got != tt.want {
+				t.Errorf("Run() output = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_printDBList(t *testing.T) {
 	tests := []struct {
 		name string
@@ -622,6 +955,39 @@ func Test_quoteOpts(t *testing.T) {
 	}
 }
 
+func Test_sqlDialect(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect string
+		driver  string
+		want    string
+	}{
+		{
+			name:   "testSQLite3",
+			driver: trdsql.DefaultDriver,
+			want:   "sqlite",
+		},
+		{
+			name:   "testPostgreSQL",
+			driver: "postgres",
+			want:   "postgres",
+		},
+		{
+			name:    "testSpecified",
+			dialect: "mysql",
+			driver:  "postgres",
+			want:    "mysql",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := sqlDialect(tt.dialect, tt.driver); got != tt.want {
+				t.Errorf("sqlDialect() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func Test_outGuessFormat(t *testing.T) {
 	type args struct {
 		fileName string
@@ -686,6 +1052,66 @@ func Test_outGuessFormat(t *testing.T) {
 			args: args{fileName: "test.jsonl.lz4"},
 			want: trdsql.JSONL,
 		},
+		{
+			name: "test.parquet",
+			args: args{fileName: "test.parquet"},
+			want: trdsql.PARQUET,
+		},
+		{
+			name: "test.xlsx",
+			args: args{fileName: "test.xlsx"},
+			want: trdsql.XLSX,
+		},
+		{
+			name: "test.feather",
+			args: args{fileName: "test.feather"},
+			want: trdsql.ARROW,
+		},
+		{
+			name: "test.avro",
+			args: args{fileName: "test.avro"},
+			want: trdsql.AVRO,
+		},
+		{
+			name: "test.xml",
+			args: args{fileName: "test.xml"},
+			want: trdsql.XML,
+		},
+		{
+			name: "test.logfmt",
+			args: args{fileName: "test.logfmt"},
+			want: trdsql.LOGFMT,
+		},
+		{
+			name: "test.html",
+			args: args{fileName: "test.html"},
+			want: trdsql.HTML,
+		},
+		{
+			name: "test.sql",
+			args: args{fileName: "test.sql"},
+			want: trdsql.SQL,
+		},
+		{
+			name: "test.adoc",
+			args: args{fileName: "test.adoc"},
+			want: trdsql.ADOC,
+		},
+		{
+			name: "test.rst",
+			args: args{fileName: "test.rst"},
+			want: trdsql.RST,
+		},
+		{
+			name: "test.tex",
+			args: args{fileName: "test.tex"},
+			want: trdsql.LATEX,
+		},
+		{
+			name: "test.org",
+			args: args{fileName: "test.org"},
+			want: trdsql.ORG,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
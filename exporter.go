@@ -44,7 +44,10 @@ func (e *WriteFormat) Export(db *DB, sql string) error {
 func (e *WriteFormat) ExportContext(ctx context.Context, db *DB, sqlQuery string) error {
 	queries := sqlss.SplitQueries(sqlQuery)
 	if !multi || len(queries) == 1 {
-		return e.exportContext(ctx, db, sqlQuery)
+		if err := e.exportContext(ctx, db, sqlQuery); err != nil {
+			return err
+		}
+		return e.finish()
 	}
 
 	e.multi = true
@@ -53,6 +56,14 @@ func (e *WriteFormat) ExportContext(ctx context.Context, db *DB, sqlQuery string
 			return err
 		}
 	}
+	return e.finish()
+}
+
+// finish calls Finish if the writer is a Finisher.
+func (e *WriteFormat) finish() error {
+	if f, ok := e.Writer.(Finisher); ok {
+		return f.Finish()
+	}
 	return nil
 }
 
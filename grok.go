@@ -0,0 +1,153 @@
+package trdsql
+
+import (
+	"errors"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrUnknownPattern is returned when the grok pattern is not defined.
+var ErrUnknownPattern = errors.New("unknown grok pattern")
+
+// grokPatterns is a library of grok-style named patterns.
+// Patterns can refer to other patterns with %{NAME}.
+// Since Go regexp (RE2) does not support lookaround,
+// some patterns are looser than the original grok patterns.
+var grokPatterns = map[string]string{
+	"USERNAME":       `[a-zA-Z0-9._-]+`,
+	"USER":           `%{USERNAME}`,
+	"EMAILLOCALPART": `[a-zA-Z0-9!#$%&'*+/=?^_{|}~-]+(?:\.[a-zA-Z0-9!#$%&'*+/=?^_{|}~-]+)*`,
+	"EMAILADDRESS":   `%{EMAILLOCALPART}@%{HOSTNAME}`,
+	"HTTPDUSER":      `%{EMAILADDRESS}|%{USER}`,
+
+	"INT":        `[+-]?[0-9]+`,
+	"BASE10NUM":  `[+-]?(?:[0-9]+(?:\.[0-9]*)?|\.[0-9]+)`,
+	"NUMBER":     `%{BASE10NUM}`,
+	"BASE16NUM":  `(?:0[xX])?[0-9A-Fa-f]+`,
+	"POSINT":     `[1-9][0-9]*`,
+	"NONNEGINT":  `[0-9]+`,
+	"WORD":       `\b\w+\b`,
+	"NOTSPACE":   `\S+`,
+	"SPACE":      `\s*`,
+	"DATA":       `.*?`,
+	"GREEDYDATA": `.*`,
+
+	"QUOTEDSTRING": `"(?:\\.|[^\\"])*"|'(?:\\.|[^\\'])*'|` + "`(?:\\\\.|[^\\\\`])*`",
+	"QS":           `%{QUOTEDSTRING}`,
+	"UUID":         `[A-Fa-f0-9]{8}-(?:[A-Fa-f0-9]{4}-){3}[A-Fa-f0-9]{12}`,
+	"MAC":          `(?:[A-Fa-f0-9]{2}[:-]){5}[A-Fa-f0-9]{2}|(?:[A-Fa-f0-9]{4}\.){2}[A-Fa-f0-9]{4}`,
+
+	"IPV4":     `(?:(?:25[0-5]|2[0-4][0-9]|1[0-9]{2}|[1-9]?[0-9])\.){3}(?:25[0-5]|2[0-4][0-9]|1[0-9]{2}|[1-9]?[0-9])`,
+	"IPV6":     `(?:[0-9A-Fa-f]{0,4}:){2,7}(?:%{IPV4}|[0-9A-Fa-f]{0,4})(?:%[0-9A-Za-z]+)?`,
+	"IP":       `%{IPV6}|%{IPV4}`,
+	"HOSTNAME": `\b[0-9A-Za-z][0-9A-Za-z-]{0,62}(?:\.[0-9A-Za-z][0-9A-Za-z-]{0,62})*\.?\b`,
+	"IPORHOST": `%{IP}|%{HOSTNAME}`,
+	"HOSTPORT": `%{IPORHOST}:%{POSINT}`,
+
+	"UNIXPATH":     `(?:/[\w_%!$@:.,+~-]*)+`,
+	"WINPATH":      `(?:[A-Za-z]+:|\\)(?:\\[^\\?*]*)+`,
+	"PATH":         `%{UNIXPATH}|%{WINPATH}`,
+	"URIPROTO":     `[A-Za-z][A-Za-z0-9+.-]*`,
+	"URIHOST":      `%{IPORHOST}(?::%{POSINT})?`,
+	"URIPATH":      `(?:/[A-Za-z0-9$.+!*'(){},~:;=@#%&_-]*)+`,
+	"URIPARAM":     `\?[A-Za-z0-9$.+!*'|(){},~@#%&/=:;_?\[\]<>-]*`,
+	"URIPATHPARAM": `%{URIPATH}(?:%{URIPARAM})?`,
+	"URI":          `%{URIPROTO}://(?:%{USER}(?::[^@]*)?@)?(?:%{URIHOST})?(?:%{URIPATHPARAM})?`,
+
+	"MONTH":             `\b(?:Jan(?:uary)?|Feb(?:ruary)?|Mar(?:ch)?|Apr(?:il)?|May|June?|July?|Aug(?:ust)?|Sep(?:tember)?|Oct(?:ober)?|Nov(?:ember)?|Dec(?:ember)?)\b`,
+	"MONTHNUM":          `0?[1-9]|1[0-2]`,
+	"MONTHDAY":          `0[1-9]|[12][0-9]|3[01]|[1-9]`,
+	"DAY":               `\b(?:Mon(?:day)?|Tue(?:sday)?|Wed(?:nesday)?|Thu(?:rsday)?|Fri(?:day)?|Sat(?:urday)?|Sun(?:day)?)\b`,
+	"YEAR":              `(?:[0-9]{2}){1,2}`,
+	"HOUR":              `2[0123]|[01]?[0-9]`,
+	"MINUTE":            `[0-5][0-9]`,
+	"SECOND":            `(?:[0-5]?[0-9]|60)(?:[:.,][0-9]+)?`,
+	"TIME":              `%{HOUR}:%{MINUTE}(?::%{SECOND})?`,
+	"DATE_US":           `%{MONTHNUM}[/-]%{MONTHDAY}[/-]%{YEAR}`,
+	"DATE_EU":           `%{MONTHDAY}[./-]%{MONTHNUM}[./-]%{YEAR}`,
+	"DATE":              `%{DATE_US}|%{DATE_EU}`,
+	"DATESTAMP":         `%{DATE}[- ]%{TIME}`,
+	"ISO8601_TIMEZONE":  `Z|[+-]%{HOUR}(?::?%{MINUTE})`,
+	"TIMESTAMP_ISO8601": `%{YEAR}-%{MONTHNUM}-%{MONTHDAY}[T ]%{HOUR}:?%{MINUTE}(?::?%{SECOND})?(?:%{ISO8601_TIMEZONE})?`,
+	"HTTPDATE":          `%{MONTHDAY}/%{MONTH}/%{YEAR}:%{TIME} %{INT}`,
+	"SYSLOGTIMESTAMP":   `%{MONTH} +%{MONTHDAY} %{TIME}`,
+	"LOGLEVEL":          `[Aa]lert|ALERT|[Tt]race|TRACE|[Dd]ebug|DEBUG|[Nn]otice|NOTICE|[Ii]nfo|INFO|[Ww]arn(?:ing)?|WARN(?:ING)?|[Ee]rr(?:or)?|ERR(?:OR)?|[Cc]rit(?:ical)?|CRIT(?:ICAL)?|[Ff]atal|FATAL|[Ss]evere|SEVERE|[Ee]merg(?:ency)?|EMERG(?:ENCY)?`,
+
+	"COMMONAPACHELOG":   `%{IPORHOST:clientip} %{HTTPDUSER:ident} %{HTTPDUSER:auth} \[%{HTTPDATE:timestamp}\] "(?:%{WORD:verb} %{NOTSPACE:request}(?: HTTP/%{NUMBER:httpversion})?|%{DATA:rawrequest})" %{INT:response:int} (?:%{INT:bytes:int}|-)`,
+	"COMBINEDAPACHELOG": `%{COMMONAPACHELOG} %{QS:referrer} %{QS:agent}`,
+}
+
+// grokMaxDepth is the maximum nesting depth of the grok patterns.
+const grokMaxDepth = 32
+
+// grokRef matches %{NAME}, %{NAME:field} and %{NAME:field:type}.
+var grokRef = regexp.MustCompile(`%\{(\w+)(?::([\w.@\[\]-]+))?(?::(int|float|string))?\}`)
+
+// grokField is a named capture group generated from %{NAME:field}.
+type grokField struct {
+	name   string
+	dbType string
+}
+
+// grokExpander expands the grok patterns into a regular expression.
+type grokExpander struct {
+	// fields maps the generated group names to the fields.
+	fields map[string]grokField
+}
+
+// expandGrok expands %{NAME:field} in the pattern into a regular expression.
+// The field becomes a named capture group,
+// and the name of the group is mapped to the field name in fields,
+// because a field name such as "client.ip" cannot be used as a group name.
+func expandGrok(pattern string) (string, map[string]grokField, error) {
+	e := &grokExpander{fields: make(map[string]grokField)}
+	expr, err := e.expand(pattern, 0)
+	if err != nil {
+		return "", nil, err
+	}
+	return expr, e.fields, nil
+}
+
+func (e *grokExpander) expand(pattern string, depth int) (string, error) {
+	if depth > grokMaxDepth {
+		return "", fmt.Errorf("%w: too deep nesting", ErrUnknownPattern)
+	}
+	var err error
+	expr := grokRef.ReplaceAllStringFunc(pattern, func(ref string) string {
+		if err != nil {
+			return ""
+		}
+		m := grokRef.FindStringSubmatch(ref)
+		def, ok := grokPatterns[m[1]]
+		if !ok {
+			err = fmt.Errorf("%w: %s", ErrUnknownPattern, m[1])
+			return ""
+		}
+		var sub string
+		sub, err = e.expand(def, depth+1)
+		if m[2] == "" {
+			return "(?:" + sub + ")"
+		}
+		group := "grok" + strconv.Itoa(len(e.fields)+1)
+		e.fields[group] = grokField{name: m[2], dbType: grokDBType(m[3])}
+		return "(?P<" + group + ">" + sub + ")"
+	})
+	if err != nil {
+		return "", err
+	}
+	return expr, nil
+}
+
+// grokDBType returns the database type of the type of %{NAME:field:type}.
+func grokDBType(t string) string {
+	switch strings.ToLower(t) {
+	case "int":
+		return "bigint"
+	case "float":
+		return "double precision"
+	default:
+		return DefaultDBType
+	}
+}
@@ -34,6 +34,9 @@ var (
 	ErrUnableConvert = errors.New("unable to convert")
 	// ErrNoMatchFound is returned if no match is found.
 	ErrNoMatchFound = errors.New("no match found")
+	// ErrMultipleFiles is returned if wildcards match multiple files
+	// of a format that cannot be concatenated, such as SQLite database files.
+	ErrMultipleFiles = errors.New("multiple files are not supported")
 	// ErrNonDefinition is returned when there is no definition.
 	ErrNonDefinition = errors.New("no definition")
 	// ErrInvalidJSON is returned when the JSON is invalid.
@@ -234,27 +237,48 @@ func ImportFile(db *DB, fileName string, readOpts *ReadOpts) (string, error) {
 func ImportFileContext(ctx context.Context, db *DB, fileName string, readOpts *ReadOpts) (string, error) {
 	opts, fileName := GuessOpts(readOpts, fileName)
 	db.importCount++
-	file, err := importFileOpen(fileName)
-	if err != nil {
-		debug.Printf("%s\n", err)
-		return "", nil
+	if opts.realFormat == SQLITE && isGlobName(fileName) {
+		if fileNames, err := globFileNames(fileName); err == nil && len(fileNames) > 1 {
+			return "", fmt.Errorf("%w: %s matches %d files", ErrMultipleFiles, fileName, len(fileNames))
+		}
 	}
-
-	defer func() {
-		if deferr := file.Close(); deferr != nil {
-			log.Printf("file close:%s", deferr)
+	var reader Reader
+	if parse, ok := configParsers[opts.realFormat]; ok && isGlobName(fileName) {
+		fileNames, err := globFileNames(fileName)
+		if err != nil {
+			debug.Printf("%s\n", err)
+			return "", nil
+		}
+		reader, err = newConfigFilesReader(fileNames, parse, opts)
+		if err != nil {
+			return "", err
+		}
+	} else {
+		file, err := importFileOpen(fileName)
+		if err != nil {
+			debug.Printf("%s\n", err)
+			return "", nil
 		}
-	}()
 
-	reader, err := NewReader(file, opts)
-	if err != nil {
-		return "", err
+		defer func() {
+			if deferr := file.Close(); deferr != nil {
+				log.Printf("file close:%s", deferr)
+			}
+		}()
+
+		reader, err = NewReader(file, opts)
+		if err != nil {
+			return "", err
+		}
 	}
 
 	tableName := fileName
 	if opts.InJQuery != "" {
 		tableName = fmt.Sprintf("%s::jq%d", fileName, db.importCount)
 	}
+	if opts.InSelector != "" {
+		tableName = fileName + "::" + opts.InSelector
+	}
 	tableName = db.QuotedName(tableName)
 
 	if opts.InRowNumber {
@@ -286,24 +310,38 @@ func ImportFileContext(ctx context.Context, db *DB, fileName string, readOpts *R
 }
 
 // GuessOpts guesses ReadOpts from the file name and sets it.
+// The part after "::" in the file name is a jq expression,
+// or a selector for formats that support it (e.g. sheet name of XLSX).
+// The returned ReadOpts is a copy so as not to affect other files.
 func GuessOpts(readOpts *ReadOpts, fileName string) (*ReadOpts, string) {
+	opts := *readOpts
+	suffix := ""
 	if _, err := os.Stat(fileName); err != nil {
 		if idx := strings.Index(fileName, "::"); idx != -1 {
-			// jq expression.
-			readOpts.InJQuery = fileName[idx+2:]
+			suffix = fileName[idx+2:]
 			fileName = fileName[:idx]
 		}
 	}
 
-	if readOpts.InFormat != GUESS {
-		readOpts.realFormat = readOpts.InFormat
-		return readOpts, fileName
+	if opts.InFormat != GUESS {
+		opts.realFormat = opts.InFormat
+	} else {
+		opts.realFormat = guessFormat(fileName)
+		if opts.realFormat != SQLITE && isSQLiteFile(fileName) {
+			opts.realFormat = SQLITE
+		}
+		debug.Printf("Guess file type as %s: [%s]", opts.realFormat, fileName)
 	}
 
-	format := guessFormat(fileName)
-	readOpts.realFormat = format
-	debug.Printf("Guess file type as %s: [%s]", readOpts.realFormat, fileName)
-	return readOpts, fileName
+	if suffix != "" {
+		if selectorFormats[opts.realFormat] {
+			opts.InSelector = suffix
+		} else {
+			// jq expression.
+			opts.InJQuery = suffix
+		}
+	}
+	return &opts, fileName
 }
 
 // guessFormat is guess format from the file name extension.
@@ -325,10 +363,17 @@ func guessFormat(fileName string) Format {
 	}
 }
 
+// globPattern matches the file name with wildcards.
+var globPattern = regexp.MustCompile(`\*|\?|\[`)
+
+// isGlobName returns true if the file name has wildcards.
+func isGlobName(fileName string) bool {
+	return globPattern.MatchString(fileName)
+}
+
 // importFileOpen opens the file specified as a table.
 func importFileOpen(tableName string) (io.ReadCloser, error) {
-	r := regexp.MustCompile(`\*|\?|\[`)
-	if r.MatchString(tableName) {
+	if isGlobName(tableName) {
 		return globFileOpen(tableName)
 	}
 	return singleFileOpen(tableName)
@@ -367,6 +412,13 @@ func uncompressedReader(reader io.Reader) io.ReadCloser {
 	}
 
 	if err != nil || r == nil {
+		// Uncompressed files are returned as they are
+		// so that formats that require random access can use them.
+		if f, ok := reader.(io.ReadSeekCloser); ok {
+			if _, err := f.Seek(0, io.SeekStart); err == nil {
+				return f
+			}
+		}
 		r = io.NopCloser(rd)
 	}
 	return r
@@ -385,16 +437,25 @@ func singleFileOpen(fileName string) (io.ReadCloser, error) {
 	return uncompressedReader(file), nil
 }
 
-// globFileOpen expands the file path,
-// connects multiple files and returns one io.PipeReader.
-func globFileOpen(globName string) (*io.PipeReader, error) {
+// globFileNames expands the file path and returns the matching file names.
+func globFileNames(globName string) ([]string, error) {
 	globName = expandTilde(trimQuote(globName))
 	fileNames, err := filepath.Glob(globName)
 	if err != nil {
 		return nil, err
 	}
 	if len(fileNames) == 0 {
-		return nil, fmt.Errorf("%w: %s", ErrNoMatchFound, fileNames)
+		return nil, fmt.Errorf("%w: %s", ErrNoMatchFound, globName)
+	}
+	return fileNames, nil
+}
+
+// globFileOpen expands the file path,
+// connects multiple files and returns one io.PipeReader.
+func globFileOpen(globName string) (*io.PipeReader, error) {
+	fileNames, err := globFileNames(globName)
+	if err != nil {
+		return nil, err
 	}
 	pipeReader, pipeWriter := io.Pipe()
 	go func() {
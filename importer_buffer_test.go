@@ -34,7 +34,7 @@ func TestNewBufferImporter(t *testing.T) {
 			args: args{
 				tableName: "testErr",
 				r:         bytes.NewBufferString("testErr"),
-				options:   []ReadOpt{InFormat(VF)},
+				options:   []ReadOpt{InFormat(RAW)},
 			},
 			want:    "testErr",
 			wantErr: true,
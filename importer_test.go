@@ -1,6 +1,7 @@
 package trdsql
 
 import (
+	"errors"
 	"io"
 	"os"
 	"path/filepath"
@@ -285,6 +286,16 @@ func TestImportFile(t *testing.T) {
 			want:    "`testdata/test.csv`",
 			wantErr: false,
 		},
+		{
+			name: "testXLSXSheet",
+			args: args{
+				db:       newDBTestSqlite3(),
+				fileName: "testdata/test.xlsx::note",
+				opts:     NewReadOpts(),
+			},
+			want:    "`testdata/test.xlsx::note`",
+			wantErr: false,
+		},
 		{
 			name: "testPostgres",
 			args: args{
@@ -334,6 +345,119 @@ func TestImportFile(t *testing.T) {
 	}
 }
 
+func TestImportFileSQLiteGlob(t *testing.T) {
+	src, err := os.ReadFile(filepath.Join(dataDir, "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	dir := t.TempDir()
+	for _, name := range []string{"a.db", "b.db"} {
+		if err := os.WriteFile(filepath.Join(dir, name), src, 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	db := newDBTestSqlite3()
+	db.Tx, err = db.Begin()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		if err := db.Tx.Commit(); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.Disconnect(); err != nil {
+			t.Fatal(err)
+		}
+	}()
+	if _, err := ImportFile(db, filepath.Join(dir, "*.db"), NewReadOpts()); !errors.Is(err, ErrMultipleFiles) {
+		t.Errorf("ImportFile() error = %v, want %v", err, ErrMultipleFiles)
+	}
+	if _, err := ImportFile(db, filepath.Join(dir, "a*.db"), NewReadOpts()); err != nil {
+		t.Errorf("ImportFile() error = %v", err)
+	}
+}
+
+func TestGuessOpts(t *testing.T) {
+	tests := []struct {
+		name         string
+		fileName     string
+		wantFileName string
+		wantFormat   Format
+		wantJQuery   string
+		wantSelector string
+	}{
+		{
+			name:         "csv",
+			fileName:     "testdata/test.csv",
+			wantFileName: "testdata/test.csv",
+			wantFormat:   CSV,
+		},
+		{
+			name:         "jq",
+			fileName:     "testdata/test.json::.[]",
+			wantFileName: "testdata/test.json",
+			wantFormat:   JSON,
+			wantJQuery:   ".[]",
+		},
+		{
+			name:         "selector",
+			fileName:     "testdata/test.xlsx::note",
+			wantFileName: "testdata/test.xlsx",
+			wantFormat:   XLSX,
+			wantSelector: "note",
+		},
+		{
+			name:         "xmlPath",
+			fileName:     "testdata/test.xml::/catalog/item",
+			wantFileName: "testdata/test.xml",
+			wantFormat:   XML,
+			wantSelector: "/catalog/item",
+		},
+		{
+			name:         "sqliteTable",
+			fileName:     "testdata/test.db::note",
+			wantFileName: "testdata/test.db",
+			wantFormat:   SQLITE,
+			wantSelector: "note",
+		},
+		{
+			name:         "tomlSection",
+			fileName:     "testdata/test.toml::servers",
+			wantFileName: "testdata/test.toml",
+			wantFormat:   TOML,
+			wantSelector: "servers",
+		},
+		{
+			name:         "htmlTable",
+			fileName:     "testdata/test.html::fruits",
+			wantFileName: "testdata/test.html",
+			wantFormat:   HTML,
+			wantSelector: "fruits",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			readOpts := NewReadOpts()
+			got, gotFileName := GuessOpts(readOpts, tt.fileName)
+			if gotFileName != tt.wantFileName {
+				t.Errorf("GuessOpts() fileName = %v, want %v", gotFileName, tt.wantFileName)
+			}
+			if got.realFormat != tt.wantFormat {
+				t.Errorf("GuessOpts() realFormat = %v, want %v", got.realFormat, tt.wantFormat)
+			}
+			if got.InJQuery != tt.wantJQuery {
+				t.Errorf("GuessOpts() InJQuery = %v, want %v", got.InJQuery, tt.wantJQuery)
+			}
+			if got.InSelector != tt.wantSelector {
+				t.Errorf("GuessOpts() InSelector = %v, want %v", got.InSelector, tt.wantSelector)
+			}
+			if readOpts.InJQuery != "" || readOpts.InSelector != "" {
+				t.Errorf("GuessOpts() modified the argument %v", readOpts)
+			}
+		})
+	}
+}
+
 func Test_guessFormat(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -350,6 +474,22 @@ func Test_guessFormat(t *testing.T) {
 		{name: "testbzip2", tableName: "test.ltsv.bz2", want: LTSV},
 		{name: "testJSON", tableName: "test.json", want: JSON},
 		{name: "testTBLN", tableName: "test.tbln", want: TBLN},
+		{name: "testPARQUET", tableName: "test.parquet", want: PARQUET},
+		{name: "testXLSX", tableName: "test.xlsx", want: XLSX},
+		{name: "testARROW", tableName: "test.arrow", want: ARROW},
+		{name: "testFEATHER", tableName: "test.feather", want: ARROW},
+		{name: "testAVRO", tableName: "test.avro", want: AVRO},
+		{name: "testXML", tableName: "test.xml", want: XML},
+		{name: "testMD", tableName: "test.md", want: MD},
+		{name: "testVF", tableName: "test.vf", want: VF},
+		{name: "testSQLITE", tableName: "test.db", want: SQLITE},
+		{name: "testSQLITE3", tableName: "test.sqlite3", want: SQLITE},
+		{name: "testLOGFMT", tableName: "test.logfmt", want: LOGFMT},
+		{name: "testTOML", tableName: "test.toml", want: TOML},
+		{name: "testINI", tableName: "test.ini", want: INI},
+		{name: "testENV", tableName: ".env", want: ENV},
+		{name: "testHTML", tableName: "test.html", want: HTML},
+		{name: "testHTM", tableName: "test.htm", want: HTML},
 		{name: "testunknown", tableName: "test.go", want: CSV},
 		{name: "testunknown2", tableName: "testltsv", want: CSV},
 	}
@@ -0,0 +1,182 @@
+package trdsql
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// accessLogRegexp matches the Apache common/combined log format,
+// which is also the default (combined) format of Nginx.
+//
+//	127.0.0.1 - frank [10/Oct/2000:13:55:36 -0700] "GET /apache_pb.gif HTTP/1.0" 200 2326 "http://www.example.com/start.html" "Mozilla/4.08"
+var accessLogRegexp = regexp.MustCompile(`^(\S+) (\S+) (\S+) \[([^\]]+)\] "((?:[^"\\]|\\.)*)" (\d{3}|-) (\d+|-)(?: "((?:[^"\\]|\\.)*)" "((?:[^"\\]|\\.)*)")?`)
+
+// accessLogTimeLayout is the layout of the time of the access log.
+const accessLogTimeLayout = "02/Jan/2006:15:04:05 -0700"
+
+// accessLogNames is the column names of the access log.
+var accessLogNames = []string{
+	"remote_addr", "ident", "remote_user", "time", "request",
+	"method", "path", "protocol", "status", "bytes", "referer", "user_agent",
+}
+
+// accessLogTypes is the column types of the access log.
+var accessLogTypes = []string{
+	"text", "text", "text", "timestamp", "text",
+	"text", "text", "text", "int", "bigint", "text", "text",
+}
+
+// AccessLogReader reads the access log of Apache (common and combined format)
+// and Nginx (default combined format).
+// The request line is split into method, path and protocol,
+// "-" is NULL (bytes is 0), and the time is converted to timestamp.
+// In the common format, referer and user_agent are NULL.
+type AccessLogReader struct {
+	reader    *bufio.Reader
+	inNULL    string
+	preRead   [][]any
+	rejected  int
+	limitRead bool
+	needNULL  bool
+}
+
+// NewAccessLogReader returns an AccessLogReader configured with input options.
+func NewAccessLogReader(reader io.Reader, opts *ReadOpts) (*AccessLogReader, error) {
+	r := &AccessLogReader{}
+	r.reader = bufio.NewReader(reader)
+
+	if opts.InSkip > 0 {
+		skipRead(r, opts.InSkip)
+	}
+
+	r.limitRead = opts.InLimitRead
+	r.needNULL = opts.InNeedNULL
+	r.inNULL = opts.InNULL
+
+	for range opts.InPreRead {
+		row := make([]any, len(accessLogNames))
+		row, err := r.read(row)
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				return r, err
+			}
+			debug.Print(err.Error())
+			return r, nil
+		}
+		r.preRead = append(r.preRead, row)
+	}
+	return r, nil
+}
+
+// Names returns column names.
+func (r *AccessLogReader) Names() ([]string, error) {
+	return accessLogNames, nil
+}
+
+// Types returns column types.
+func (r *AccessLogReader) Types() ([]string, error) {
+	return accessLogTypes, nil
+}
+
+// PreReadRow is returns only columns that store preread rows.
+func (r *AccessLogReader) PreReadRow() [][]any {
+	return r.preRead
+}
+
+// ReadRow is read the rest of the row.
+// Lines that are not in the access log format are skipped.
+func (r *AccessLogReader) ReadRow(row []any) ([]any, error) {
+	if r.limitRead {
+		return nil, io.EOF
+	}
+	return r.read(row)
+}
+
+func (r *AccessLogReader) read(row []any) ([]any, error) {
+	for {
+		line, err := r.reader.ReadString('\n')
+		if line == "" && err != nil {
+			if errors.Is(err, io.EOF) && r.rejected > 0 {
+				log.Printf("WARN: access log: %d line(s) did not match", r.rejected)
+				r.rejected = 0
+			}
+			return row, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if line == "" {
+			continue
+		}
+		m := accessLogRegexp.FindStringSubmatch(line)
+		if m == nil {
+			debug.Printf("access log: unmatched line: %s", line)
+			r.rejected++
+			continue
+		}
+		values := r.values(m)
+		for i := 0; i < len(row) && i < len(values); i++ {
+			row[i] = values[i]
+			if r.needNULL {
+				row[i] = replaceNULL(r.inNULL, row[i])
+			}
+		}
+		return row, nil
+	}
+}
+
+// values converts the submatches to the values of the columns.
+func (r *AccessLogReader) values(m []string) []any {
+	request := unescapeLog(m[5])
+	values := []any{
+		logField(m[1]), logField(m[2]), logField(m[3]), accessLogTime(m[4]), logField(request),
+		nil, nil, nil, nil, int64(0), nil, nil,
+	}
+	if parts := strings.Fields(request); len(parts) == 3 {
+		values[5], values[6], values[7] = parts[0], parts[1], parts[2]
+	} else if len(parts) == 2 {
+		// HTTP/0.9 has no protocol.
+		values[5], values[6] = parts[0], parts[1]
+	}
+	if status, err := strconv.Atoi(m[6]); err == nil {
+		values[8] = status
+	}
+	if bytes, err := strconv.ParseInt(m[7], 10, 64); err == nil {
+		values[9] = bytes
+	}
+	if m[8] != "" || m[9] != "" {
+		values[10] = logField(unescapeLog(m[8]))
+		values[11] = logField(unescapeLog(m[9]))
+	}
+	return values
+}
+
+// accessLogTime returns the time of the access log as time.Time.
+// If it cannot be parsed, it returns the string as it is.
+func accessLogTime(s string) any {
+	t, err := time.Parse(accessLogTimeLayout, s)
+	if err != nil {
+		return s
+	}
+	return t
+}
+
+// logField returns nil if the field of the log is "-".
+func logField(s string) any {
+	if s == "-" {
+		return nil
+	}
+	return s
+}
+
+// unescapeLog unescapes \" and \\ in the quoted field of the log.
+func unescapeLog(s string) string {
+	if !strings.Contains(s, `\`) {
+		return s
+	}
+	return strings.NewReplacer(`\"`, `"`, `\\`, `\`).Replace(s)
+}
@@ -0,0 +1,93 @@
+package trdsql
+
+import (
+	"io"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewAccessLogReader(t *testing.T) {
+	file, err := singleFileOpen(filepath.Join(dataDir, "access.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	r, err := NewAccessLogReader(file, NewReadOpts(InPreRead(2)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	names, err := r.Names()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != len(accessLogTypes) {
+		t.Fatalf("AccessLogReader names %v and types %v do not match", names, accessLogTypes)
+	}
+	loc := time.FixedZone("", -7*60*60)
+	want := [][]any{
+		{
+			"127.0.0.1", nil, "frank", time.Date(2000, 10, 10, 13, 55, 36, 0, loc), "GET /apache_pb.gif HTTP/1.0",
+			"GET", "/apache_pb.gif", "HTTP/1.0", 200, int64(2326), "http://www.example.com/start.html", "Mozilla/4.08 [en] (Win98; I ;Nav)",
+		},
+		{
+			"::1", nil, nil, time.Date(2000, 10, 10, 13, 55, 37, 0, loc), "POST /login HTTP/1.1",
+			"POST", "/login", "HTTP/1.1", 302, int64(0), nil, "curl/7.68.0",
+		},
+	}
+	got := r.PreReadRow()
+	if len(got) != len(want) {
+		t.Fatalf("AccessLogReader.PreReadRow() = %v, want %v", got, want)
+	}
+	for i := range want {
+		for j := range want[i] {
+			if wt, ok := want[i][j].(time.Time); ok {
+				if gt, ok := got[i][j].(time.Time); !ok || !gt.Equal(wt) {
+					t.Errorf("AccessLogReader.PreReadRow()[%d][%s] = %v, want %v", i, names[j], got[i][j], wt)
+				}
+				continue
+			}
+			if !reflect.DeepEqual(got[i][j], want[i][j]) {
+				t.Errorf("AccessLogReader.PreReadRow()[%d][%s] = %v, want %v", i, names[j], got[i][j], want[i][j])
+			}
+		}
+	}
+
+	// The unmatched line is skipped.
+	row := make([]any, len(names))
+	row, err = r.ReadRow(row)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if row[0] != "192.168.0.1" {
+		t.Errorf("AccessLogReader.ReadRow() = %v, want %v", row[0], "192.168.0.1")
+	}
+	if _, err := r.ReadRow(row); err != io.EOF {
+		t.Errorf("AccessLogReader.ReadRow() error = %v, want %v", err, io.EOF)
+	}
+}
+
+func TestAccessLogReaderCommon(t *testing.T) {
+	const common = `10.0.0.1 - - [08/Jan/2022:10:20:30 +0900] "GET /a\"b HTTP/1.1" 200 -` + "\n" +
+		`10.0.0.2 - - [08/Jan/2022:10:20:31 +0900] "-" 400 0` + "\n"
+	r, err := NewAccessLogReader(strings.NewReader(common), NewReadOpts(InPreRead(2)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	rows := r.PreReadRow()
+	if len(rows) != 2 {
+		t.Fatalf("AccessLogReader.PreReadRow() = %v", rows)
+	}
+	// request, method, path, protocol, status, bytes, referer, user_agent
+	want := [][]any{
+		{`GET /a"b HTTP/1.1`, "GET", `/a"b`, "HTTP/1.1", 200, int64(0), nil, nil},
+		{nil, nil, nil, nil, 400, int64(0), nil, nil},
+	}
+	for i := range want {
+		if got := rows[i][4:]; !reflect.DeepEqual(got, want[i]) {
+			t.Errorf("AccessLogReader.PreReadRow()[%d] = %v, want %v", i, got, want[i])
+		}
+	}
+}
@@ -0,0 +1,164 @@
+package trdsql
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/apache/arrow-go/v18/arrow"
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+)
+
+// ArrowReader reads Apache Arrow IPC files (Feather V2) and streams.
+// Column types are converted from the Arrow schema,
+// and rows are read for each record batch.
+type ArrowReader struct {
+	rows      arrowRows
+	inNULL    string
+	names     []string
+	types     []string
+	preRead   [][]any
+	limitRead bool
+	needNULL  bool
+}
+
+// NewArrowReader returns an ArrowReader configured with input options.
+// The IPC file format is distinguished from the IPC stream format by the magic number.
+func NewArrowReader(reader io.Reader, opts *ReadOpts) (*ArrowReader, error) {
+	r := &ArrowReader{}
+	records, schema, err := arrowIPCRecords(reader)
+	if err != nil {
+		return nil, fmt.Errorf("arrow: %w", err)
+	}
+	r.rows.records = records
+	r.names = make([]string, schema.NumFields())
+	r.types = make([]string, schema.NumFields())
+	for i, field := range schema.Fields() {
+		r.names[i] = field.Name
+		r.types[i] = arrowDBType(field.Type)
+	}
+
+	if opts.InSkip > 0 {
+		skipRead(r, opts.InSkip)
+	}
+
+	r.limitRead = opts.InLimitRead
+	r.needNULL = opts.InNeedNULL
+	r.inNULL = opts.InNULL
+
+	for range opts.InPreRead {
+		row := make([]any, len(r.names))
+		row, err := r.read(row)
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				return r, err
+			}
+			debug.Print(err.Error())
+			return r, nil
+		}
+		r.preRead = append(r.preRead, row)
+	}
+	return r, nil
+}
+
+// arrowIPCRecords returns the record batches and schema of the Arrow IPC data.
+// The stream format is read as a stream,
+// and the file format is read with random access.
+func arrowIPCRecords(reader io.Reader) (arrowRecords, *arrow.Schema, error) {
+	br := bufio.NewReader(reader)
+	magic, _ := br.Peek(len(ipc.Magic))
+	if !bytes.Equal(magic, ipc.Magic) {
+		rr, err := ipc.NewReader(br)
+		if err != nil {
+			return nil, nil, err
+		}
+		return rr, rr.Schema(), nil
+	}
+
+	ra, ok := reader.(readerAtSeeker)
+	if !ok {
+		var err error
+		if ra, err = randomAccessReader(br); err != nil {
+			return nil, nil, err
+		}
+	}
+	fr, err := ipc.NewFileReader(ra)
+	if err != nil {
+		return nil, nil, err
+	}
+	return &arrowFileRecords{file: fr}, fr.Schema(), nil
+}
+
+// arrowFileRecords iterates over the record batches of an Arrow IPC file.
+type arrowFileRecords struct {
+	file   *ipc.FileReader
+	record arrow.RecordBatch
+	err    error
+}
+
+func (f *arrowFileRecords) Next() bool {
+	rec, err := f.file.Read()
+	if err != nil {
+		if !errors.Is(err, io.EOF) {
+			f.err = err
+		}
+		f.record = nil
+		return false
+	}
+	f.record = rec
+	return true
+}
+
+func (f *arrowFileRecords) RecordBatch() arrow.RecordBatch {
+	return f.record
+}
+
+func (f *arrowFileRecords) Err() error {
+	return f.err
+}
+
+func (f *arrowFileRecords) Release() {
+	if err := f.file.Close(); err != nil {
+		debug.Printf("arrow: %s", err)
+	}
+}
+
+// Names returns column names.
+func (r *ArrowReader) Names() ([]string, error) {
+	return r.names, nil
+}
+
+// Types returns column types.
+// The types are converted from the Arrow data types.
+func (r *ArrowReader) Types() ([]string, error) {
+	return r.types, nil
+}
+
+// PreReadRow is returns only columns that store preread rows.
+func (r *ArrowReader) PreReadRow() [][]any {
+	return r.preRead
+}
+
+// ReadRow is read the rest of the row.
+func (r *ArrowReader) ReadRow(row []any) ([]any, error) {
+	if r.limitRead {
+		return nil, io.EOF
+	}
+	return r.read(row)
+}
+
+// read reads one row and replaces NULL.
+func (r *ArrowReader) read(row []any) ([]any, error) {
+	row, err := r.rows.read(row)
+	if err != nil {
+		return row, err
+	}
+	if r.needNULL {
+		for i := range row {
+			row[i] = replaceNULL(r.inNULL, row[i])
+		}
+	}
+	return row, nil
+}
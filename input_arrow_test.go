@@ -0,0 +1,137 @@
+package trdsql
+
+import (
+	"bytes"
+	"io"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+)
+
+func TestNewArrowReader(t *testing.T) {
+	tests := []struct {
+		name        string
+		fileName    string
+		opts        *ReadOpts
+		wantNames   []string
+		wantTypes   []string
+		wantPreRead [][]any
+		wantErr     bool
+	}{
+		{
+			name:        "test.arrow",
+			fileName:    "test.arrow",
+			opts:        NewReadOpts(),
+			wantNames:   []string{"id", "name", "price", "day"},
+			wantTypes:   []string{"bigint", "text", "double precision", "date"},
+			wantPreRead: [][]any{{int64(1), "Orange", 50.5, "2022-01-08"}},
+			wantErr:     false,
+		},
+		{
+			name:        "skip",
+			fileName:    "test.arrow",
+			opts:        NewReadOpts(InSkip(2)),
+			wantNames:   []string{"id", "name", "price", "day"},
+			wantTypes:   []string{"bigint", "text", "double precision", "date"},
+			wantPreRead: [][]any{{int64(3), "Apple", nil, "2022-01-10"}},
+			wantErr:     false,
+		},
+		{
+			name:        "inNULL",
+			fileName:    "test.arrow",
+			opts:        NewReadOpts(InNeedNULL(true), InNULL("Orange")),
+			wantNames:   []string{"id", "name", "price", "day"},
+			wantTypes:   []string{"bigint", "text", "double precision", "date"},
+			wantPreRead: [][]any{{int64(1), nil, 50.5, "2022-01-08"}},
+			wantErr:     false,
+		},
+		{
+			name:     "notArrow",
+			fileName: "test.csv",
+			opts:     NewReadOpts(),
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := singleFileOpen(filepath.Join(dataDir, tt.fileName))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer file.Close()
+			got, err := NewArrowReader(file, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewArrowReader() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got.names, tt.wantNames) {
+				t.Errorf("NewArrowReader().names = %v, want %v", got.names, tt.wantNames)
+			}
+			if !reflect.DeepEqual(got.types, tt.wantTypes) {
+				t.Errorf("NewArrowReader().types = %v, want %v", got.types, tt.wantTypes)
+			}
+			if !reflect.DeepEqual(got.PreReadRow(), tt.wantPreRead) {
+				t.Errorf("NewArrowReader().PreReadRow() = %v, want %v", got.PreReadRow(), tt.wantPreRead)
+			}
+		})
+	}
+}
+
+func TestArrowReaderStream(t *testing.T) {
+	// Convert test.arrow to the IPC stream format.
+	file, err := singleFileOpen(filepath.Join(dataDir, "test.arrow"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	b, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fr, err := ipc.NewFileReader(bytes.NewReader(b))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fr.Close()
+	stream := new(bytes.Buffer)
+	sw := ipc.NewWriter(stream, ipc.WithSchema(fr.Schema()))
+	for i := 0; i < fr.NumRecords(); i++ {
+		rec, err := fr.RecordBatch(i)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := sw.Write(rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := sw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewArrowReader(stream, NewReadOpts())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]any{
+		{int64(2), "Melon", 500.0, "2022-01-09"},
+		{int64(3), "Apple", nil, "2022-01-10"},
+	}
+	for _, w := range want {
+		row := make([]any, len(r.names))
+		got, err := r.ReadRow(row)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, w) {
+			t.Errorf("ArrowReader.ReadRow() = %v, want %v", got, w)
+		}
+	}
+	row := make([]any, len(r.names))
+	if _, err := r.ReadRow(row); err != io.EOF {
+		t.Errorf("ArrowReader.ReadRow() error = %v, want %v", err, io.EOF)
+	}
+}
@@ -0,0 +1,267 @@
+package trdsql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"math/big"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/hamba/avro/v2"
+	"github.com/hamba/avro/v2/ocf"
+)
+
+// AvroReader reads Apache Avro Object Container Files.
+// Column names and types are taken from the schema embedded in the file.
+// Fields of nested records are flattened into columns joined by ".",
+// and unions, arrays and maps are converted to JSON strings.
+type AvroReader struct {
+	decoder   *ocf.Decoder
+	columns   []avroColumn
+	record    bool
+	inNULL    string
+	names     []string
+	types     []string
+	preRead   [][]any
+	limitRead bool
+	needNULL  bool
+}
+
+// avroColumn is a column flattened from the Avro schema.
+type avroColumn struct {
+	path   []string
+	schema avro.Schema
+}
+
+// NewAvroReader returns an AvroReader configured with input options.
+func NewAvroReader(reader io.Reader, opts *ReadOpts) (*AvroReader, error) {
+	r := &AvroReader{}
+	dec, err := ocf.NewDecoder(reader)
+	if err != nil {
+		return nil, fmt.Errorf("avro: %w", err)
+	}
+	r.decoder = dec
+
+	schema := avroResolve(dec.Schema())
+	if rec, ok := schema.(*avro.RecordSchema); ok {
+		r.record = true
+		r.columns = avroColumns(nil, rec)
+	} else {
+		r.columns = []avroColumn{{path: []string{"c1"}, schema: schema}}
+	}
+	r.names = make([]string, len(r.columns))
+	r.types = make([]string, len(r.columns))
+	for i, col := range r.columns {
+		r.names[i] = strings.Join(col.path, ".")
+		r.types[i] = avroDBType(col.schema)
+	}
+
+	if opts.InSkip > 0 {
+		skipRead(r, opts.InSkip)
+	}
+
+	r.limitRead = opts.InLimitRead
+	r.needNULL = opts.InNeedNULL
+	r.inNULL = opts.InNULL
+
+	for range opts.InPreRead {
+		row := make([]any, len(r.names))
+		row, err := r.read(row)
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				return r, err
+			}
+			debug.Print(err.Error())
+			return r, nil
+		}
+		r.preRead = append(r.preRead, row)
+	}
+	return r, nil
+}
+
+// avroResolve returns the schema referenced by the named reference.
+func avroResolve(schema avro.Schema) avro.Schema {
+	if ref, ok := schema.(*avro.RefSchema); ok {
+		return ref.Schema()
+	}
+	return schema
+}
+
+// avroColumns returns the columns of the record.
+// Nested records are flattened.
+func avroColumns(parent []string, rec *avro.RecordSchema) []avroColumn {
+	columns := make([]avroColumn, 0, len(rec.Fields()))
+	for _, field := range rec.Fields() {
+		path := append(append([]string{}, parent...), field.Name())
+		schema := avroResolve(field.Type())
+		if child, ok := schema.(*avro.RecordSchema); ok {
+			columns = append(columns, avroColumns(path, child)...)
+			continue
+		}
+		columns = append(columns, avroColumn{path: path, schema: schema})
+	}
+	return columns
+}
+
+// avroNullable returns the non-null type of the union of null and one type.
+func avroNullable(schema avro.Schema) avro.Schema {
+	union, ok := schema.(*avro.UnionSchema)
+	if !ok || !union.Nullable() || len(union.Types()) != 2 {
+		return schema
+	}
+	for _, s := range union.Types() {
+		if s.Type() != avro.Null {
+			return avroResolve(s)
+		}
+	}
+	return schema
+}
+
+// avroDBType returns the database type corresponding to the Avro schema.
+func avroDBType(schema avro.Schema) string {
+	schema = avroNullable(schema)
+	var logical avro.LogicalType
+	if ls, ok := schema.(avro.LogicalTypeSchema); ok && ls.Logical() != nil {
+		logical = ls.Logical().Type()
+	}
+	switch logical {
+	case avro.Date:
+		return "date"
+	case avro.TimestampMillis, avro.TimestampMicros, avro.LocalTimestampMillis, avro.LocalTimestampMicros:
+		return "timestamp"
+	case avro.Decimal:
+		return "numeric"
+	}
+	switch schema.Type() {
+	case avro.Boolean:
+		return "bool"
+	case avro.Int:
+		return "int"
+	case avro.Long:
+		return "bigint"
+	case avro.Float, avro.Double:
+		return "double precision"
+	default:
+		return DefaultDBType
+	}
+}
+
+// Names returns column names.
+func (r *AvroReader) Names() ([]string, error) {
+	return r.names, nil
+}
+
+// Types returns column types.
+// The types are converted from the Avro schema.
+func (r *AvroReader) Types() ([]string, error) {
+	return r.types, nil
+}
+
+// PreReadRow is returns only columns that store preread rows.
+func (r *AvroReader) PreReadRow() [][]any {
+	return r.preRead
+}
+
+// ReadRow is read the rest of the row.
+func (r *AvroReader) ReadRow(row []any) ([]any, error) {
+	if r.limitRead {
+		return nil, io.EOF
+	}
+	return r.read(row)
+}
+
+func (r *AvroReader) read(row []any) ([]any, error) {
+	if !r.decoder.HasNext() {
+		if err := r.decoder.Error(); err != nil {
+			return row, fmt.Errorf("avro: %w", err)
+		}
+		return row, io.EOF
+	}
+	var v any
+	if r.record {
+		var m map[string]any
+		if err := r.decoder.Decode(&m); err != nil {
+			return row, fmt.Errorf("avro: %w", err)
+		}
+		v = m
+	} else if err := r.decoder.Decode(&v); err != nil {
+		return row, fmt.Errorf("avro: %w", err)
+	}
+
+	for i := 0; i < len(row) && i < len(r.columns); i++ {
+		col := r.columns[i]
+		val := v
+		if r.record {
+			val = avroLookup(v, col.path)
+		}
+		row[i] = avroValue(val, col.schema)
+		if r.needNULL {
+			row[i] = replaceNULL(r.inNULL, row[i])
+		}
+	}
+	return row, nil
+}
+
+// avroLookup returns the value of the path in the decoded record.
+func avroLookup(v any, path []string) any {
+	for _, name := range path {
+		m, ok := v.(map[string]any)
+		if !ok {
+			return nil
+		}
+		v = m[name]
+	}
+	return v
+}
+
+// avroValue returns the decoded Avro value
+// as a value that can be passed to the database.
+func avroValue(v any, schema avro.Schema) any {
+	switch t := v.(type) {
+	case nil:
+		return nil
+	case int:
+		return int64(t)
+	case int32:
+		return int64(t)
+	case float32:
+		return float64(t)
+	case []byte:
+		return string(t)
+	case time.Time:
+		if avroDBType(schema) == "date" {
+			return t.Format(time.DateOnly)
+		}
+		return t
+	case time.Duration:
+		return t.String()
+	case *big.Rat:
+		scale := 0
+		if ls, ok := avroNullable(schema).(avro.LogicalTypeSchema); ok {
+			if d, ok := ls.Logical().(*avro.DecimalLogicalSchema); ok {
+				scale = d.Scale()
+			}
+		}
+		return t.FloatString(scale)
+	case bool, int64, float64, string:
+		return v
+	case map[string]any, []any:
+		b, err := json.Marshal(t)
+		if err != nil {
+			log.Printf("ERROR: avroValue:%s", err)
+		}
+		return ValString(b)
+	default:
+		// fixed is decoded as a byte array.
+		if rv := reflect.ValueOf(v); rv.Kind() == reflect.Array && rv.Type().Elem().Kind() == reflect.Uint8 {
+			b := make([]byte, rv.Len())
+			reflect.Copy(reflect.ValueOf(b), rv)
+			return string(b)
+		}
+		return ValString(v)
+	}
+}
@@ -0,0 +1,109 @@
+package trdsql
+
+import (
+	"io"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestNewAvroReader(t *testing.T) {
+	names := []string{"id", "name", "price", "day", "origin.country", "origin.code", "tags", "extra"}
+	types := []string{"bigint", "text", "double precision", "date", "text", "int", "text", "text"}
+	tests := []struct {
+		name        string
+		fileName    string
+		opts        *ReadOpts
+		wantPreRead [][]any
+		wantErr     bool
+	}{
+		{
+			name:     "test.avro",
+			fileName: "test.avro",
+			opts:     NewReadOpts(),
+			wantPreRead: [][]any{
+				{int64(1), "Orange", 50.5, "2022-01-08", "Japan", int64(81), `["citrus"]`, nil},
+			},
+			wantErr: false,
+		},
+		{
+			name:     "skip",
+			fileName: "test.avro",
+			opts:     NewReadOpts(InSkip(2)),
+			wantPreRead: [][]any{
+				{int64(3), "Apple", nil, "2022-01-10", "USA", int64(1), `["red","sweet"]`, int64(7)},
+			},
+			wantErr: false,
+		},
+		{
+			name:     "inNULL",
+			fileName: "test.avro",
+			opts:     NewReadOpts(InNeedNULL(true), InNULL("Japan")),
+			wantPreRead: [][]any{
+				{int64(1), "Orange", 50.5, "2022-01-08", nil, int64(81), `["citrus"]`, nil},
+			},
+			wantErr: false,
+		},
+		{
+			name:     "notAvro",
+			fileName: "test.csv",
+			opts:     NewReadOpts(),
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := singleFileOpen(filepath.Join(dataDir, tt.fileName))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer file.Close()
+			got, err := NewAvroReader(file, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewAvroReader() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got.names, names) {
+				t.Errorf("NewAvroReader().names = %v, want %v", got.names, names)
+			}
+			if !reflect.DeepEqual(got.types, types) {
+				t.Errorf("NewAvroReader().types = %v, want %v", got.types, types)
+			}
+			if !reflect.DeepEqual(got.PreReadRow(), tt.wantPreRead) {
+				t.Errorf("NewAvroReader().PreReadRow() = %v, want %v", got.PreReadRow(), tt.wantPreRead)
+			}
+		})
+	}
+}
+
+func TestAvroReader_ReadRow(t *testing.T) {
+	file, err := singleFileOpen(filepath.Join(dataDir, "test.avro"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	r, err := NewAvroReader(file, NewReadOpts())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]any{
+		{int64(2), "Melon", 500.0, "2022-01-09", "Japan", int64(81), `[]`, "big"},
+		{int64(3), "Apple", nil, "2022-01-10", "USA", int64(1), `["red","sweet"]`, int64(7)},
+	}
+	for _, w := range want {
+		row := make([]any, len(r.names))
+		got, err := r.ReadRow(row)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, w) {
+			t.Errorf("AvroReader.ReadRow() = %v, want %v", got, w)
+		}
+	}
+	row := make([]any, len(r.names))
+	if _, err := r.ReadRow(row); err != io.EOF {
+		t.Errorf("AvroReader.ReadRow() error = %v, want %v", err, io.EOF)
+	}
+}
@@ -0,0 +1,425 @@
+package trdsql
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrNoSection is returned when the specified section does not exist.
+var ErrNoSection = errors.New("no such section")
+
+// configKeyColumn is the name of the column that stores the name of the sub-table.
+const configKeyColumn = "_key"
+
+// configFileColumn is the name of the column that stores the file name
+// when multiple files are read with wildcards.
+const configFileColumn = "_file"
+
+// configParsers is the parsers of the configuration files.
+// The files matching the wildcards are parsed one by one
+// because they cannot be concatenated (e.g. the same table in TOML).
+var configParsers = map[Format]func(io.Reader) (*configTable, error){
+	TOML: parseTOML,
+	INI:  parseINI,
+	ENV:  parseENV,
+}
+
+// configNames is the column names of the flattened configuration file.
+var configNames = []string{"section", "key", "value", "type"}
+
+// configTable is a table of the configuration file that keeps the order of the keys.
+// The values are *configTable, []*configTable (array of tables) or values.
+type configTable struct {
+	keys   []string
+	values map[string]any
+}
+
+func newConfigTable() *configTable {
+	return &configTable{values: make(map[string]any)}
+}
+
+// set sets the value of the key. The order of the first set is kept.
+func (t *configTable) set(key string, value any) {
+	if _, ok := t.values[key]; !ok {
+		t.keys = append(t.keys, key)
+	}
+	t.values[key] = value
+}
+
+// ConfigReader reads configuration files (TOML, INI and .env).
+// By default, the file is flattened into rows of (section, key, value, type),
+// and section is NULL for the keys outside of the sections.
+//
+// With InSelector (config.toml::servers), the rows are the tables of the section.
+// An array of tables is one row per table, a table of tables is one row per table
+// with the name in the _key column, and the other table is one row.
+//
+// When the file name has wildcards, each file is parsed on its own,
+// and the file name is in the first column(_file).
+type ConfigReader struct {
+	names     []string
+	types     []string
+	rows      [][]any
+	preRead   [][]any
+	inNULL    string
+	limitRead bool
+	needNULL  bool
+}
+
+// newConfigReader returns a ConfigReader of the parsed table.
+func newConfigReader(root *configTable, opts *ReadOpts) (*ConfigReader, error) {
+	r := &ConfigReader{}
+	if err := r.load(root, opts.InSelector); err != nil {
+		return nil, err
+	}
+	return r.setup(opts)
+}
+
+// newConfigFilesReader returns a ConfigReader of the files parsed one by one.
+// The columns are the union of the columns of the files.
+// The files without the section of InSelector are skipped.
+func newConfigFilesReader(fileNames []string, parse func(io.Reader) (*configTable, error), opts *ReadOpts) (*ConfigReader, error) {
+	r := &ConfigReader{}
+	r.names = []string{configFileColumn}
+	index := map[string]int{configFileColumn: 0}
+	for _, fileName := range fileNames {
+		root, err := parseConfigFile(fileName, parse)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", fileName, err)
+		}
+		file := &ConfigReader{}
+		if err := file.load(root, opts.InSelector); err != nil {
+			if errors.Is(err, ErrNoSection) {
+				debug.Printf("%s: %s", fileName, err)
+				continue
+			}
+			return nil, fmt.Errorf("%s: %w", fileName, err)
+		}
+		for _, name := range file.names {
+			if _, ok := index[name]; !ok {
+				index[name] = len(r.names)
+				r.names = append(r.names, name)
+			}
+		}
+		for _, record := range file.rows {
+			row := make([]any, len(r.names))
+			row[0] = fileName
+			for i, v := range record {
+				row[index[file.names[i]]] = v
+			}
+			r.rows = append(r.rows, row)
+		}
+	}
+	// The rows of the earlier files have fewer columns.
+	for i, row := range r.rows {
+		if len(row) < len(r.names) {
+			r.rows[i] = append(row, make([]any, len(r.names)-len(row))...)
+		}
+	}
+	return r.setup(opts)
+}
+
+// parseConfigFile opens the file and parses it.
+func parseConfigFile(fileName string, parse func(io.Reader) (*configTable, error)) (*configTable, error) {
+	debug.Printf("Open: [%s]", fileName)
+	file, err := singleFileOpen(fileName)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return parse(file)
+}
+
+// load sets the names and the rows of the parsed table.
+func (r *ConfigReader) load(root *configTable, selector string) error {
+	if selector == "" {
+		r.names = configNames
+		r.rows = root.flatten(nil, nil)
+		return nil
+	}
+	return r.selectSection(root, selector)
+}
+
+// setup sets the types and reads the rows of InSkip and InPreRead.
+func (r *ConfigReader) setup(opts *ReadOpts) (*ConfigReader, error) {
+	r.types = make([]string, len(r.names))
+	for i := range r.names {
+		r.types[i] = DefaultDBType
+	}
+
+	if opts.InSkip > 0 {
+		skipRead(r, opts.InSkip)
+	}
+
+	r.limitRead = opts.InLimitRead
+	r.needNULL = opts.InNeedNULL
+	r.inNULL = opts.InNULL
+
+	for range opts.InPreRead {
+		row := make([]any, len(r.names))
+		row, err := r.read(row)
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				return r, err
+			}
+			debug.Print(err.Error())
+			return r, nil
+		}
+		r.preRead = append(r.preRead, row)
+	}
+	return r, nil
+}
+
+// flatten returns the rows of (section, key, value, type).
+// The arrays of tables are sections with the index (products[0]).
+func (t *configTable) flatten(section any, rows [][]any) [][]any {
+	for _, key := range t.keys {
+		switch v := t.values[key].(type) {
+		case *configTable:
+			rows = v.flatten(configSection(section, key), rows)
+		case []*configTable:
+			for i, sub := range v {
+				rows = sub.flatten(configSection(section, key)+"["+strconv.Itoa(i)+"]", rows)
+			}
+		default:
+			rows = append(rows, []any{section, key, configValue(v), configType(v)})
+		}
+	}
+	return rows
+}
+
+// configSection returns the name of the sub-section.
+func configSection(section any, key string) string {
+	if section == nil {
+		return key
+	}
+	return section.(string) + "." + key
+}
+
+// lookup returns the value of the dotted path.
+// The key containing dots (e.g. the INI section "a.b") is also found.
+func (t *configTable) lookup(path string) (any, bool) {
+	if v, ok := t.values[path]; ok {
+		return v, true
+	}
+	for i := 0; i < len(path); i++ {
+		if path[i] != '.' {
+			continue
+		}
+		sub, ok := t.values[path[:i]].(*configTable)
+		if !ok {
+			continue
+		}
+		if v, ok := sub.lookup(path[i+1:]); ok {
+			return v, true
+		}
+	}
+	return nil, false
+}
+
+// selectSection sets the rows of the section.
+func (r *ConfigReader) selectSection(root *configTable, selector string) error {
+	v, ok := root.lookup(selector)
+	if !ok {
+		return fmt.Errorf("%w: %s", ErrNoSection, selector)
+	}
+	var tables []*configTable
+	var subKeys []string
+	switch v := v.(type) {
+	case []*configTable:
+		tables = v
+	case *configTable:
+		tables = []*configTable{v}
+		if subs, ok := v.subTables(); ok {
+			tables, subKeys = subs, v.keys
+			r.names = append(r.names, configKeyColumn)
+		}
+	default:
+		return fmt.Errorf("%w: %s is not a table", ErrNoSection, selector)
+	}
+
+	already := make(map[string]bool)
+	for _, name := range r.names {
+		already[name] = true
+	}
+	for _, t := range tables {
+		for _, key := range t.keys {
+			if !already[key] {
+				already[key] = true
+				r.names = append(r.names, key)
+			}
+		}
+	}
+	for i, t := range tables {
+		row := make([]any, len(r.names))
+		for j, name := range r.names {
+			if subKeys != nil && j == 0 {
+				row[j] = subKeys[i]
+				continue
+			}
+			if v, ok := t.values[name]; ok {
+				row[j] = configColumn(v)
+			}
+		}
+		r.rows = append(r.rows, row)
+	}
+	return nil
+}
+
+// subTables returns the tables if all values of the table are tables.
+func (t *configTable) subTables() ([]*configTable, bool) {
+	if len(t.keys) == 0 {
+		return nil, false
+	}
+	tables := make([]*configTable, 0, len(t.keys))
+	for _, key := range t.keys {
+		sub, ok := t.values[key].(*configTable)
+		if !ok {
+			return nil, false
+		}
+		tables = append(tables, sub)
+	}
+	return tables, true
+}
+
+// configColumn returns the value of the column.
+// Tables and arrays are JSON, and times are strings.
+func configColumn(v any) any {
+	switch v := v.(type) {
+	case string, int64, float64, bool:
+		return v
+	default:
+		return configValue(v)
+	}
+}
+
+// configValue returns the value as a string.
+func configValue(v any) string {
+	switch v := v.(type) {
+	case string:
+		return v
+	case int64:
+		return strconv.FormatInt(v, 10)
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	case bool:
+		return strconv.FormatBool(v)
+	case time.Time:
+		return configTime(v)
+	default:
+		b, err := json.Marshal(configJSON(v))
+		if err != nil {
+			return fmt.Sprint(v)
+		}
+		return string(b)
+	}
+}
+
+// configJSON converts the value into a value that can be marshaled as JSON in order.
+func configJSON(v any) any {
+	switch v := v.(type) {
+	case *configTable:
+		var b strings.Builder
+		b.WriteByte('{')
+		for i, key := range v.keys {
+			if i > 0 {
+				b.WriteByte(',')
+			}
+			k, _ := json.Marshal(key)
+			b.Write(k)
+			b.WriteByte(':')
+			value, err := json.Marshal(configJSON(v.values[key]))
+			if err != nil {
+				value = []byte("null")
+			}
+			b.Write(value)
+		}
+		b.WriteByte('}')
+		return json.RawMessage(b.String())
+	case []*configTable:
+		list := make([]any, len(v))
+		for i, t := range v {
+			list[i] = configJSON(t)
+		}
+		return list
+	case []any:
+		list := make([]any, len(v))
+		for i, e := range v {
+			list[i] = configJSON(e)
+		}
+		return list
+	case time.Time:
+		return configTime(v)
+	default:
+		return v
+	}
+}
+
+// configType returns the type name of the value.
+func configType(v any) string {
+	switch v := v.(type) {
+	case string:
+		return "string"
+	case int64:
+		return "integer"
+	case float64:
+		return "float"
+	case bool:
+		return "boolean"
+	case time.Time:
+		switch v.Location().String() {
+		case tomlLocalDate:
+			return "date"
+		case tomlLocalTime:
+			return "time"
+		default:
+			return "datetime"
+		}
+	default:
+		return "array"
+	}
+}
+
+// Names returns column names.
+func (r *ConfigReader) Names() ([]string, error) {
+	return r.names, nil
+}
+
+// Types returns column types.
+// All config types return the DefaultDBType.
+func (r *ConfigReader) Types() ([]string, error) {
+	return r.types, nil
+}
+
+// PreReadRow is returns only columns that store preread rows.
+func (r *ConfigReader) PreReadRow() [][]any {
+	return r.preRead
+}
+
+// ReadRow is read the rest of the row.
+func (r *ConfigReader) ReadRow(row []any) ([]any, error) {
+	if r.limitRead {
+		return nil, io.EOF
+	}
+	return r.read(row)
+}
+
+func (r *ConfigReader) read(row []any) ([]any, error) {
+	if len(r.rows) == 0 {
+		return row, io.EOF
+	}
+	record := r.rows[0]
+	r.rows = r.rows[1:]
+	for i := 0; i < len(row) && i < len(record); i++ {
+		row[i] = record[i]
+		if r.needNULL {
+			row[i] = replaceNULL(r.inNULL, row[i])
+		}
+	}
+	return row, nil
+}
@@ -0,0 +1,276 @@
+package trdsql
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewTOMLReader(t *testing.T) {
+	tests := []struct {
+		name      string
+		selector  string
+		wantNames []string
+		wantRows  [][]any
+		wantErr   error
+	}{
+		{
+			name:      "flatten",
+			wantNames: []string{"section", "key", "value", "type"},
+			wantRows: [][]any{
+				{nil, "title", "TOML Example", "string"},
+				{nil, "updated", "1979-05-27", "date"},
+				{"owner", "name", "Tom Preston-Werner", "string"},
+				{"owner", "dob", "1979-05-27T07:32:00-08:00", "datetime"},
+				{"database", "enabled", "true", "boolean"},
+				{"database", "ports", "[8000,8001,8002]", "array"},
+				{"database.temp_targets", "cpu", "79.5", "float"},
+				{"database.temp_targets", "case", "72", "float"},
+				{"servers.alpha", "ip", "10.0.0.1", "string"},
+				{"servers.alpha", "role", "frontend", "string"},
+				{"servers.beta", "ip", "10.0.0.2", "string"},
+				{"servers.beta", "role", "backend", "string"},
+				{"products[0]", "name", "Hammer", "string"},
+				{"products[0]", "sku", "738594937", "integer"},
+				{"products[1]", "name", "Nail", "string"},
+				{"products[1]", "sku", "284758393", "integer"},
+				{"products[1]", "color", "gray", "string"},
+			},
+		},
+		{
+			name:      "arrayOfTables",
+			selector:  "products",
+			wantNames: []string{"name", "sku", "color"},
+			wantRows: [][]any{
+				{"Hammer", int64(738594937), nil},
+				{"Nail", int64(284758393), "gray"},
+			},
+		},
+		{
+			name:      "tableOfTables",
+			selector:  "servers",
+			wantNames: []string{"_key", "ip", "role"},
+			wantRows: [][]any{
+				{"alpha", "10.0.0.1", "frontend"},
+				{"beta", "10.0.0.2", "backend"},
+			},
+		},
+		{
+			name:      "table",
+			selector:  "database",
+			wantNames: []string{"enabled", "ports", "temp_targets"},
+			wantRows: [][]any{
+				{true, "[8000,8001,8002]", `{"cpu":79.5,"case":72}`},
+			},
+		},
+		{
+			name:      "dotted",
+			selector:  "servers.beta",
+			wantNames: []string{"ip", "role"},
+			wantRows: [][]any{
+				{"10.0.0.2", "backend"},
+			},
+		},
+		{
+			name:     "noSection",
+			selector: "nothing",
+			wantErr:  ErrNoSection,
+		},
+		{
+			name:     "notTable",
+			selector: "title",
+			wantErr:  ErrNoSection,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := singleFileOpen(filepath.Join(dataDir, "test.toml"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer file.Close()
+			r, err := NewTOMLReader(file, NewReadOpts(InSelector(tt.selector), InPreRead(100)))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewTOMLReader() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if names, _ := r.Names(); !reflect.DeepEqual(names, tt.wantNames) {
+				t.Errorf("NewTOMLReader().Names() = %v, want %v", names, tt.wantNames)
+			}
+			if got := r.PreReadRow(); !reflect.DeepEqual(got, tt.wantRows) {
+				t.Errorf("NewTOMLReader().PreReadRow() = %v, want %v", got, tt.wantRows)
+			}
+		})
+	}
+}
+
+func TestNewTOMLReaderInvalid(t *testing.T) {
+	if _, err := NewTOMLReader(strings.NewReader("a = "), NewReadOpts()); err == nil {
+		t.Error("NewTOMLReader() error = nil, want error")
+	}
+}
+
+func TestNewINIReader(t *testing.T) {
+	tests := []struct {
+		name      string
+		selector  string
+		wantNames []string
+		wantRows  [][]any
+	}{
+		{
+			name:      "flatten",
+			wantNames: []string{"section", "key", "value", "type"},
+			wantRows: [][]any{
+				{nil, "debug", "false", "string"},
+				{"server", "host", "localhost", "string"},
+				{"server", "port", "8080", "string"},
+				{"database.main", "user", "admin", "string"},
+				{"database.main", "password", "p;a#ss", "string"},
+				{"database.main", "readonly", "", "string"},
+			},
+		},
+		{
+			name:      "section",
+			selector:  "database.main",
+			wantNames: []string{"user", "password", "readonly"},
+			wantRows: [][]any{
+				{"admin", "p;a#ss", ""},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := singleFileOpen(filepath.Join(dataDir, "test.ini"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer file.Close()
+			r, err := NewINIReader(file, NewReadOpts(InSelector(tt.selector), InPreRead(100)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if names, _ := r.Names(); !reflect.DeepEqual(names, tt.wantNames) {
+				t.Errorf("NewINIReader().Names() = %v, want %v", names, tt.wantNames)
+			}
+			if got := r.PreReadRow(); !reflect.DeepEqual(got, tt.wantRows) {
+				t.Errorf("NewINIReader().PreReadRow() = %v, want %v", got, tt.wantRows)
+			}
+		})
+	}
+}
+
+func TestNewENVReader(t *testing.T) {
+	file, err := singleFileOpen(filepath.Join(dataDir, "test.env"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	r, err := NewENVReader(file, NewReadOpts(InSkip(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]any{
+		{nil, "DB_PORT", "5432", "string"},
+		{nil, "GREETING", "hello\nworld", "string"},
+		{nil, "LITERAL", "$HOME", "string"},
+	}
+	got := r.PreReadRow()
+	for {
+		row := make([]any, len(configNames))
+		row, err := r.ReadRow(row)
+		if err != nil {
+			break
+		}
+		got = append(got, row)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("ENVReader rows = %v, want %v", got, want)
+	}
+}
+
+func TestConfigGlob(t *testing.T) {
+	dir := t.TempDir()
+	files := map[string]string{
+		"a/config.toml": "[server]\nhost = \"a\"\nport = 1\n",
+		"b/config.toml": "[server]\nhost = \"b\"\n[db]\nname = \"x\"\n",
+		"a/config.ini":  "[s]\nk = 1\n",
+		"b/config.ini":  "[s]\nk = 2\n",
+	}
+	for name, data := range files {
+		fileName := filepath.Join(dir, name)
+		if err := os.MkdirAll(filepath.Dir(fileName), 0o755); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(fileName, []byte(data), 0o644); err != nil {
+			t.Fatal(err)
+		}
+	}
+	aTOML, bTOML := filepath.Join(dir, "a", "config.toml"), filepath.Join(dir, "b", "config.toml")
+	aINI, bINI := filepath.Join(dir, "a", "config.ini"), filepath.Join(dir, "b", "config.ini")
+	tests := []struct {
+		name      string
+		fileName  string
+		wantNames []string
+		want      [][]any
+	}{
+		{
+			name:      "toml",
+			fileName:  filepath.Join(dir, "*", "config.toml"),
+			wantNames: []string{"_file", "section", "key", "value", "type"},
+			want: [][]any{
+				{aTOML, "server", "host", "a", "string"},
+				{aTOML, "server", "port", "1", "integer"},
+				{bTOML, "server", "host", "b", "string"},
+				{bTOML, "db", "name", "x", "string"},
+			},
+		},
+		{
+			name:      "tomlSection",
+			fileName:  filepath.Join(dir, "*", "config.toml") + "::server",
+			wantNames: []string{"_file", "host", "port"},
+			want: [][]any{
+				{aTOML, "a", int64(1)},
+				{bTOML, "b", nil},
+			},
+		},
+		{
+			name:      "tomlSectionMissing",
+			fileName:  filepath.Join(dir, "*", "config.toml") + "::db",
+			wantNames: []string{"_file", "name"},
+			want:      [][]any{{bTOML, "x"}},
+		},
+		{
+			name:      "ini",
+			fileName:  filepath.Join(dir, "*", "config.ini"),
+			wantNames: []string{"_file", "section", "key", "value", "type"},
+			want: [][]any{
+				{aINI, "s", "k", "1", "string"},
+				{bINI, "s", "k", "2", "string"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts, fileName := GuessOpts(NewReadOpts(InPreRead(10)), tt.fileName)
+			fileNames, err := globFileNames(fileName)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r, err := newConfigFilesReader(fileNames, configParsers[opts.realFormat], opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(r.names, tt.wantNames) {
+				t.Errorf("ConfigReader.names = %v, want %v", r.names, tt.wantNames)
+			}
+			if got := r.PreReadRow(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ConfigReader.PreReadRow() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,87 @@
+package trdsql
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// NewENVReader returns a ConfigReader of the dotenv(.env) file.
+//
+//	# comment
+//	export KEY=value
+//	QUOTED="line1\nline2"
+//	LITERAL='$NOT_EXPANDED'
+//
+// All keys have no section, and all values are strings.
+// Variables in the values are not expanded.
+func NewENVReader(reader io.Reader, opts *ReadOpts) (*ConfigReader, error) {
+	root, err := parseENV(reader)
+	if err != nil {
+		return nil, err
+	}
+	return newConfigReader(root, opts)
+}
+
+// parseENV parses the dotenv file into configTable.
+func parseENV(reader io.Reader) (*configTable, error) {
+	root := newConfigTable()
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := strings.TrimSpace(strings.TrimPrefix(scanner.Text(), "\ufeff"))
+		if line == "" || line[0] == '#' {
+			continue
+		}
+		line = strings.TrimPrefix(line, "export ")
+		key, value, ok := strings.Cut(line, "=")
+		if !ok {
+			debug.Printf("env: invalid line: %s", line)
+			continue
+		}
+		root.set(strings.TrimSpace(key), envValue(strings.TrimSpace(value)))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// envValue returns the value without the quotes or the comment.
+// Escapes(\n, \t, \", \\) are replaced in double quotes.
+func envValue(value string) string {
+	if len(value) >= 2 && value[0] == '\'' {
+		if end := strings.IndexByte(value[1:], '\''); end >= 0 {
+			return value[1 : end+1]
+		}
+	}
+	if len(value) >= 2 && value[0] == '"' {
+		var b strings.Builder
+		for i := 1; i < len(value); i++ {
+			c := value[i]
+			if c == '"' {
+				return b.String()
+			}
+			if c == '\\' && i+1 < len(value) {
+				i++
+				switch value[i] {
+				case 'n':
+					b.WriteByte('\n')
+				case 't':
+					b.WriteByte('\t')
+				case 'r':
+					b.WriteByte('\r')
+				default:
+					b.WriteByte(value[i])
+				}
+				continue
+			}
+			b.WriteByte(c)
+		}
+		return value
+	}
+	if i := strings.Index(value, " #"); i >= 0 {
+		value = strings.TrimSpace(value[:i])
+	}
+	return value
+}
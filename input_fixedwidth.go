@@ -0,0 +1,229 @@
+package trdsql
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	runewidth "github.com/mattn/go-runewidth"
+)
+
+// ErrInvalidWidths is returned when the column widths specification is invalid.
+var ErrInvalidWidths = errors.New("invalid widths")
+
+// widthColumn is a column of the fixed-width file.
+// start and end are 0-based display positions, and end is -1 to the end of the line.
+type widthColumn struct {
+	name  string
+	start int
+	end   int
+}
+
+// FWReader reads a fixed-width file with the column positions specified by InWidths.
+// The positions are counted by display width (East Asian wide characters are 2),
+// and a character that straddles the boundary belongs to the column where it starts.
+// Values are trimmed of the surrounding spaces.
+type FWReader struct {
+	reader    *bufio.Reader
+	columns   []widthColumn
+	inNULL    string
+	names     []string
+	types     []string
+	preRead   [][]any
+	limitRead bool
+	needNULL  bool
+}
+
+// NewFWReader returns an FWReader configured with input options.
+// If the widths have no names, the first line is the header with InHeader,
+// otherwise the column names are c1, c2...
+func NewFWReader(reader io.Reader, opts *ReadOpts) (*FWReader, error) {
+	r := &FWReader{}
+	r.reader = bufio.NewReader(reader)
+	columns, err := parseWidths(opts.InWidths)
+	if err != nil {
+		return nil, err
+	}
+	r.columns = columns
+
+	for range opts.InSkip {
+		if _, err := r.readLine(); err != nil {
+			break
+		}
+	}
+
+	r.limitRead = opts.InLimitRead
+	r.needNULL = opts.InNeedNULL
+	r.inNULL = opts.InNULL
+
+	r.names = make([]string, len(columns))
+	r.types = make([]string, len(columns))
+	for i, column := range columns {
+		r.names[i] = column.name
+		if r.names[i] == "" {
+			r.names[i] = "c" + strconv.Itoa(i+1)
+		}
+		r.types[i] = DefaultDBType
+	}
+	if opts.InHeader {
+		line, err := r.readLine()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				return r, err
+			}
+			debug.Print(err.Error())
+			return r, nil
+		}
+		for i, v := range splitWidths(line, columns) {
+			if columns[i].name == "" && v != "" {
+				r.names[i] = v
+			}
+		}
+	}
+
+	for range opts.InPreRead {
+		row := make([]any, len(r.names))
+		row, err := r.read(row)
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				return r, err
+			}
+			debug.Print(err.Error())
+			return r, nil
+		}
+		r.preRead = append(r.preRead, row)
+	}
+	return r, nil
+}
+
+// parseWidths parses the specification of the column positions.
+// Each column is separated by ',' and is one of the following,
+// and can be prefixed with the name of the column("name:1-8").
+//
+//	1-8  the 1st to 8th display positions (1-based, inclusive)
+//	21-  from the 21st position to the end of the line
+//	8    the width following the previous column
+func parseWidths(spec string) ([]widthColumn, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, fmt.Errorf("%w: empty", ErrInvalidWidths)
+	}
+	var columns []widthColumn
+	pos := 0
+	for _, field := range strings.Split(spec, ",") {
+		field = strings.TrimSpace(field)
+		name, rng, ok := strings.Cut(field, ":")
+		if !ok {
+			name, rng = "", field
+		}
+		name = strings.TrimSpace(name)
+		rng = strings.TrimSpace(rng)
+		column := widthColumn{name: name}
+		if from, to, ok := strings.Cut(rng, "-"); ok {
+			start, err := strconv.Atoi(from)
+			if err != nil || start < 1 {
+				return nil, fmt.Errorf("%w: %q", ErrInvalidWidths, field)
+			}
+			column.start, column.end = start-1, -1
+			if to != "" {
+				end, err := strconv.Atoi(to)
+				if err != nil || end < start {
+					return nil, fmt.Errorf("%w: %q", ErrInvalidWidths, field)
+				}
+				column.end = end
+			}
+		} else {
+			width, err := strconv.Atoi(rng)
+			if err != nil || width < 1 {
+				return nil, fmt.Errorf("%w: %q", ErrInvalidWidths, field)
+			}
+			column.start, column.end = pos, pos+width
+		}
+		if column.end < 0 {
+			pos = column.start
+		} else {
+			pos = column.end
+		}
+		columns = append(columns, column)
+	}
+	return columns, nil
+}
+
+// splitWidths splits the line into the columns by display position.
+func splitWidths(line string, columns []widthColumn) []string {
+	values := make([]string, len(columns))
+	for i, column := range columns {
+		var b strings.Builder
+		pos := 0
+		for _, c := range line {
+			if pos >= column.start && (column.end < 0 || pos < column.end) {
+				b.WriteRune(c)
+			}
+			pos += runewidth.RuneWidth(c)
+			if column.end >= 0 && pos >= column.end {
+				break
+			}
+		}
+		values[i] = strings.TrimSpace(b.String())
+	}
+	return values
+}
+
+// Names returns column names.
+func (r *FWReader) Names() ([]string, error) {
+	return r.names, nil
+}
+
+// Types returns column types.
+// All FW types return the DefaultDBType.
+func (r *FWReader) Types() ([]string, error) {
+	return r.types, nil
+}
+
+// PreReadRow is returns only columns that store preread rows.
+func (r *FWReader) PreReadRow() [][]any {
+	return r.preRead
+}
+
+// ReadRow is read the rest of the row.
+func (r *FWReader) ReadRow(row []any) ([]any, error) {
+	if r.limitRead {
+		return nil, io.EOF
+	}
+	return r.read(row)
+}
+
+func (r *FWReader) read(row []any) ([]any, error) {
+	var line string
+	for {
+		var err error
+		line, err = r.readLine()
+		if err != nil {
+			return row, err
+		}
+		if strings.TrimSpace(line) != "" {
+			break
+		}
+	}
+	for i, v := range splitWidths(line, r.columns) {
+		if i >= len(row) {
+			break
+		}
+		row[i] = v
+		if r.needNULL {
+			row[i] = replaceNULL(r.inNULL, row[i])
+		}
+	}
+	return row, nil
+}
+
+// readLine reads a line without the line terminator.
+func (r *FWReader) readLine() (string, error) {
+	line, err := r.reader.ReadString('\n')
+	if line == "" && err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
@@ -0,0 +1,125 @@
+package trdsql
+
+import (
+	"errors"
+	"io"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewFWReader(t *testing.T) {
+	tests := []struct {
+		name        string
+		reader      io.Reader
+		opts        *ReadOpts
+		wantNames   []string
+		wantPreRead [][]any
+		wantErr     error
+	}{
+		{
+			name:      "ranges",
+			opts:      NewReadOpts(InWidths("1-8,9-20,21-"), InPreRead(3)),
+			wantNames: []string{"c1", "c2", "c3"},
+			wantPreRead: [][]any{
+				{"00000001", "Orange", "50"},
+				{"00000002", "メロン", "500"},
+				{"00000003", "Apple", "100"},
+			},
+		},
+		{
+			name:      "namedWidths",
+			opts:      NewReadOpts(InWidths("id:8,name:12,price:21-"), InPreRead(1)),
+			wantNames: []string{"id", "name", "price"},
+			wantPreRead: [][]any{
+				{"00000001", "Orange", "50"},
+			},
+		},
+		{
+			name:      "header",
+			reader:    strings.NewReader("ID  NAME\n1   Orange\n"),
+			opts:      NewReadOpts(InWidths("1-4,5-"), InHeader(true)),
+			wantNames: []string{"ID", "NAME"},
+			wantPreRead: [][]any{
+				{"1", "Orange"},
+			},
+		},
+		{
+			name:      "straddle",
+			reader:    strings.NewReader("aあいう\n"),
+			opts:      NewReadOpts(InWidths("1-2,3-")),
+			wantNames: []string{"c1", "c2"},
+			wantPreRead: [][]any{
+				{"aあ", "いう"},
+			},
+		},
+		{
+			name:    "invalid",
+			reader:  strings.NewReader(""),
+			opts:    NewReadOpts(InWidths("8-1")),
+			wantErr: ErrInvalidWidths,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reader := tt.reader
+			if reader == nil {
+				file, err := singleFileOpen(filepath.Join(dataDir, "fixed.txt"))
+				if err != nil {
+					t.Fatal(err)
+				}
+				defer file.Close()
+				reader = file
+			}
+			got, err := NewFWReader(reader, tt.opts)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewFWReader() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if !reflect.DeepEqual(got.names, tt.wantNames) {
+				t.Errorf("NewFWReader().names = %v, want %v", got.names, tt.wantNames)
+			}
+			if got := got.PreReadRow(); !reflect.DeepEqual(got, tt.wantPreRead) {
+				t.Errorf("NewFWReader().PreReadRow() = %v, want %v", got, tt.wantPreRead)
+			}
+		})
+	}
+}
+
+func Test_parseWidths(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    []widthColumn
+		wantErr bool
+	}{
+		{
+			name: "ranges",
+			spec: "1-8,9-20,21-",
+			want: []widthColumn{{start: 0, end: 8}, {start: 8, end: 20}, {start: 20, end: -1}},
+		},
+		{
+			name: "widths",
+			spec: "id:8, name:12, 3",
+			want: []widthColumn{{name: "id", start: 0, end: 8}, {name: "name", start: 8, end: 20}, {start: 20, end: 23}},
+		},
+		{name: "empty", spec: "", wantErr: true},
+		{name: "zero", spec: "0-3", wantErr: true},
+		{name: "reverse", spec: "5-3", wantErr: true},
+		{name: "notNumber", spec: "a,b", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseWidths(tt.spec)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("parseWidths() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseWidths() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
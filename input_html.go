@@ -0,0 +1,359 @@
+package trdsql
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"golang.org/x/net/html"
+	"golang.org/x/net/html/atom"
+	"golang.org/x/net/html/charset"
+)
+
+// htmlMaxSpan is the maximum value of colspan and rowspan.
+const htmlMaxSpan = 1000
+
+// HTMLReader reads a <table> of an HTML file.
+// The table is selected by 1-based index or id with InSelector
+// (file.html::2, file.html::status), and the first table is used if it is not specified.
+//
+// The rows of <thead> (or the first row if all cells are <th>) are the column names.
+// The cells with colspan and rowspan are repeated in the spanned cells,
+// and the cell text is stripped of the markup.
+// The document is decoded with the charset of the BOM or <meta>,
+// and is UTF-8 if it is not declared.
+type HTMLReader struct {
+	names     []string
+	types     []string
+	rows      [][]string
+	preRead   [][]any
+	inNULL    string
+	limitRead bool
+	needNULL  bool
+}
+
+// NewHTMLReader returns an HTMLReader configured with input options.
+func NewHTMLReader(reader io.Reader, opts *ReadOpts) (*HTMLReader, error) {
+	r := &HTMLReader{}
+	reader, err := htmlCharsetReader(reader)
+	if err != nil {
+		return nil, fmt.Errorf("html: %w", err)
+	}
+	doc, err := html.Parse(reader)
+	if err != nil {
+		return nil, fmt.Errorf("html: %w", err)
+	}
+	table, err := htmlTable(doc, opts.InSelector)
+	if err != nil {
+		return nil, err
+	}
+
+	header, rows := htmlGrid(table)
+	r.setNames(header, rows)
+	r.rows = rows
+
+	if opts.InSkip > 0 {
+		skipRead(r, opts.InSkip)
+	}
+
+	r.limitRead = opts.InLimitRead
+	r.needNULL = opts.InNeedNULL
+	r.inNULL = opts.InNULL
+
+	for range opts.InPreRead {
+		row := make([]any, len(r.names))
+		row, err := r.read(row)
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				return r, err
+			}
+			debug.Print(err.Error())
+			return r, nil
+		}
+		r.preRead = append(r.preRead, row)
+	}
+	return r, nil
+}
+
+// htmlCharsetReader returns the reader that decodes the charset
+// declared by the BOM or <meta> in the first 1024 bytes.
+// Undeclared documents are read as UTF-8 as they are,
+// instead of the windows-1252 fallback of the charset package.
+func htmlCharsetReader(reader io.Reader) (io.Reader, error) {
+	br := bufio.NewReader(reader)
+	head, err := br.Peek(1024)
+	if err != nil && !errors.Is(err, io.EOF) {
+		return nil, err
+	}
+	e, name, certain := charset.DetermineEncoding(head, "")
+	if name == "utf-8" {
+		return br, nil
+	}
+	if !certain && name == "windows-1252" && !bytes.Contains(bytes.ToLower(head), []byte("charset")) {
+		return br, nil
+	}
+	debug.Printf("html charset: %s", name)
+	return e.NewDecoder().Reader(br), nil
+}
+
+// htmlTable returns the table specified by the selector.
+// The selector is the id of the table or a 1-based index.
+func htmlTable(doc *html.Node, selector string) (*html.Node, error) {
+	var tables []*html.Node
+	var find func(n *html.Node)
+	find = func(n *html.Node) {
+		if n.Type == html.ElementNode && n.DataAtom == atom.Table {
+			tables = append(tables, n)
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			find(c)
+		}
+	}
+	find(doc)
+	if len(tables) == 0 {
+		return nil, fmt.Errorf("html: %w", ErrNoTable)
+	}
+	if selector == "" {
+		return tables[0], nil
+	}
+	for _, table := range tables {
+		if htmlAttr(table, "id") == selector {
+			return table, nil
+		}
+	}
+	if n, err := strconv.Atoi(selector); err == nil && n > 0 && n <= len(tables) {
+		return tables[n-1], nil
+	}
+	return nil, fmt.Errorf("html: %w: %s", ErrNoTable, selector)
+}
+
+// htmlAttr returns the value of the attribute.
+func htmlAttr(n *html.Node, key string) string {
+	for _, attr := range n.Attr {
+		if attr.Key == key {
+			return attr.Val
+		}
+	}
+	return ""
+}
+
+// htmlRow is a <tr> of the table.
+type htmlRow struct {
+	tr     *html.Node
+	header bool
+}
+
+// htmlRows returns the rows of the table, excluding the rows of nested tables.
+// The rows of <thead> are header rows.
+func htmlRows(table *html.Node) []htmlRow {
+	var rows []htmlRow
+	for c := table.FirstChild; c != nil; c = c.NextSibling {
+		if c.Type != html.ElementNode {
+			continue
+		}
+		switch c.DataAtom {
+		case atom.Tr:
+			rows = append(rows, htmlRow{tr: c})
+		case atom.Thead, atom.Tbody, atom.Tfoot:
+			for tr := c.FirstChild; tr != nil; tr = tr.NextSibling {
+				if tr.Type == html.ElementNode && tr.DataAtom == atom.Tr {
+					rows = append(rows, htmlRow{tr: tr, header: c.DataAtom == atom.Thead})
+				}
+			}
+		}
+	}
+	return rows
+}
+
+// htmlGrid returns the header rows and the body rows of the table.
+// The cells with colspan and rowspan are repeated.
+// If there is no <thead>, the first row is the header if all cells are <th>.
+func htmlGrid(table *html.Node) ([][]string, [][]string) {
+	rows := htmlRows(table)
+	hasHead := false
+	for _, row := range rows {
+		if row.header {
+			hasHead = true
+			break
+		}
+	}
+
+	type span struct {
+		text string
+		left int
+	}
+	var spans []span
+	var header, body [][]string
+	for n, row := range rows {
+		var cells []string
+		allTH := true
+		col := 0
+		fill := func() {
+			for col < len(spans) && spans[col].left > 0 {
+				cells = append(cells, spans[col].text)
+				spans[col].left--
+				col++
+			}
+		}
+		for td := row.tr.FirstChild; td != nil; td = td.NextSibling {
+			if td.Type != html.ElementNode || (td.DataAtom != atom.Td && td.DataAtom != atom.Th) {
+				continue
+			}
+			if td.DataAtom != atom.Th {
+				allTH = false
+			}
+			fill()
+			text := htmlText(td)
+			colspan := htmlSpan(td, "colspan")
+			rowspan := htmlSpan(td, "rowspan")
+			for range colspan {
+				for len(spans) <= col {
+					spans = append(spans, span{})
+				}
+				spans[col] = span{text: text, left: rowspan - 1}
+				cells = append(cells, text)
+				col++
+			}
+		}
+		fill()
+		for ; col < len(spans); col++ {
+			if spans[col].left > 0 {
+				for len(cells) < col {
+					cells = append(cells, "")
+				}
+				cells = append(cells, spans[col].text)
+				spans[col].left--
+			}
+		}
+		if len(cells) == 0 {
+			continue
+		}
+		if row.header || (!hasHead && n == 0 && allTH) {
+			header = append(header, cells)
+			continue
+		}
+		body = append(body, cells)
+	}
+	return header, body
+}
+
+// htmlSpan returns the value of colspan or rowspan.
+func htmlSpan(n *html.Node, key string) int {
+	v, err := strconv.Atoi(strings.TrimSpace(htmlAttr(n, key)))
+	if err != nil || v < 1 {
+		return 1
+	}
+	return min(v, htmlMaxSpan)
+}
+
+// htmlNewline replaces the newlines in the source with spaces.
+var htmlNewline = strings.NewReplacer("\r\n", " ", "\n", " ", "\r", " ")
+
+// htmlText returns the text of the node without the markup.
+// Whitespace is collapsed, and <br> is a newline.
+func htmlText(n *html.Node) string {
+	var b strings.Builder
+	var walk func(n *html.Node)
+	walk = func(n *html.Node) {
+		switch n.Type {
+		case html.TextNode:
+			b.WriteString(htmlNewline.Replace(n.Data))
+			return
+		case html.ElementNode:
+			switch n.DataAtom {
+			case atom.Script, atom.Style:
+				return
+			case atom.Br:
+				b.WriteByte('\n')
+				return
+			}
+		}
+		for c := n.FirstChild; c != nil; c = c.NextSibling {
+			walk(c)
+		}
+	}
+	walk(n)
+	lines := strings.Split(b.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.Join(strings.Fields(line), " ")
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+// setNames sets the column names from the header rows.
+// The texts of multiple header rows are joined with a space,
+// and duplicate names are suffixed with _2, _3...
+func (r *HTMLReader) setNames(header [][]string, rows [][]string) {
+	n := 0
+	for _, row := range append(header, rows...) {
+		n = max(n, len(row))
+	}
+	r.names = make([]string, n)
+	r.types = make([]string, n)
+	used := make(map[string]bool)
+	for i := range n {
+		var parts []string
+		for _, row := range header {
+			if i < len(row) && row[i] != "" && (len(parts) == 0 || parts[len(parts)-1] != row[i]) {
+				parts = append(parts, strings.ReplaceAll(row[i], "\n", " "))
+			}
+		}
+		name := strings.Join(parts, " ")
+		if name == "" {
+			name = "c" + strconv.Itoa(i+1)
+		}
+		base := name
+		for k := 2; used[name]; k++ {
+			name = base + "_" + strconv.Itoa(k)
+		}
+		used[name] = true
+		r.names[i] = name
+		r.types[i] = DefaultDBType
+	}
+}
+
+// Names returns column names.
+func (r *HTMLReader) Names() ([]string, error) {
+	return r.names, nil
+}
+
+// Types returns column types.
+// All HTML types return the DefaultDBType.
+func (r *HTMLReader) Types() ([]string, error) {
+	return r.types, nil
+}
+
+// PreReadRow is returns only columns that store preread rows.
+func (r *HTMLReader) PreReadRow() [][]any {
+	return r.preRead
+}
+
+// ReadRow is read the rest of the row.
+func (r *HTMLReader) ReadRow(row []any) ([]any, error) {
+	if r.limitRead {
+		return nil, io.EOF
+	}
+	return r.read(row)
+}
+
+func (r *HTMLReader) read(row []any) ([]any, error) {
+	if len(r.rows) == 0 {
+		return row, io.EOF
+	}
+	record := r.rows[0]
+	r.rows = r.rows[1:]
+	for i := range row {
+		row[i] = nil
+		if i < len(record) {
+			row[i] = record[i]
+		}
+		if r.needNULL {
+			row[i] = replaceNULL(r.inNULL, row[i])
+		}
+	}
+	return row, nil
+}
@@ -0,0 +1,159 @@
+package trdsql
+
+import (
+	"errors"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewHTMLReader(t *testing.T) {
+	tests := []struct {
+		name      string
+		selector  string
+		wantNames []string
+		wantRows  [][]any
+		wantErr   error
+	}{
+		{
+			name:      "first",
+			wantNames: []string{"Service", "Latency (ms) p50", "Latency (ms) p99", "Status"},
+			wantRows: [][]any{
+				{"API", "12", "85", "OK"},
+				{"Web", "20", "140", "OK"},
+				{"Batch", "n/a", "n/a", "Degraded\nsince 10:00"},
+			},
+		},
+		{
+			name:      "index",
+			selector:  "2",
+			wantNames: []string{"id", "name", "price"},
+			wantRows: [][]any{
+				{"1", "Orange", "50"},
+				{"2", "Melon & Kiwi", "500"},
+				{"3", "Apple", ""},
+			},
+		},
+		{
+			name:      "id",
+			selector:  "status",
+			wantNames: []string{"Service", "Latency (ms) p50", "Latency (ms) p99", "Status"},
+			wantRows: [][]any{
+				{"API", "12", "85", "OK"},
+				{"Web", "20", "140", "OK"},
+				{"Batch", "n/a", "n/a", "Degraded\nsince 10:00"},
+			},
+		},
+		{
+			name:     "noTable",
+			selector: "3",
+			wantErr:  ErrNoTable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := singleFileOpen(filepath.Join(dataDir, "test.html"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer file.Close()
+			r, err := NewHTMLReader(file, NewReadOpts(InSelector(tt.selector), InPreRead(100)))
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewHTMLReader() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if names, _ := r.Names(); !reflect.DeepEqual(names, tt.wantNames) {
+				t.Errorf("NewHTMLReader().Names() = %v, want %v", names, tt.wantNames)
+			}
+			if got := r.PreReadRow(); !reflect.DeepEqual(got, tt.wantRows) {
+				t.Errorf("NewHTMLReader().PreReadRow() = %v, want %v", got, tt.wantRows)
+			}
+		})
+	}
+}
+
+func TestHTMLReaderGrid(t *testing.T) {
+	tests := []struct {
+		name      string
+		html      string
+		wantNames []string
+		wantRows  [][]any
+	}{
+		{
+			name:      "noHeader",
+			html:      `<table><tr><td>1</td><td>a</td></tr><tr><td>2</td></tr></table>`,
+			wantNames: []string{"c1", "c2"},
+			wantRows:  [][]any{{"1", "a"}, {"2", nil}},
+		},
+		{
+			name:      "duplicateNames",
+			html:      `<table><tr><th colspan="2">x</th><th></th></tr><tr><td>1</td><td>2</td><td>3</td></tr></table>`,
+			wantNames: []string{"x", "x_2", "c3"},
+			wantRows:  [][]any{{"1", "2", "3"}},
+		},
+		{
+			name:      "duplicateSuffix",
+			html:      `<table><tr><th>a</th><th>a</th><th>a_2</th></tr><tr><td>1</td><td>2</td><td>3</td></tr></table>`,
+			wantNames: []string{"a", "a_2", "a_2_2"},
+			wantRows:  [][]any{{"1", "2", "3"}},
+		},
+		{
+			name: "metaCharset",
+			html: `<html><head><meta charset="Shift_JIS"></head><body>` +
+				`<table><tr><th>name</th></tr><tr><td>` + "\x83\x81\x83\x8d\x83\x93" + `</td></tr></table></body></html>`,
+			wantNames: []string{"name"},
+			wantRows:  [][]any{{"メロン"}},
+		},
+		{
+			name: "contentType",
+			html: `<meta http-equiv="Content-Type" content="text/html; charset=iso-8859-1">` +
+				`<table><tr><td>caf` + "\xe9" + `</td></tr></table>`,
+			wantNames: []string{"c1"},
+			wantRows:  [][]any{{"café"}},
+		},
+		{
+			name:      "undeclaredUTF8",
+			html:      `<table><tr><td>` + strings.Repeat(" ", 1100) + `メロン</td></tr></table>`,
+			wantNames: []string{"c1"},
+			wantRows:  [][]any{{"メロン"}},
+		},
+		{
+			name: "rowspanLast",
+			html: `<table><tr><th>a</th><th>b</th></tr>` +
+				`<tr><td>1</td><td rowspan="2">x</td></tr><tr><td>2</td></tr></table>`,
+			wantNames: []string{"a", "b"},
+			wantRows:  [][]any{{"1", "x"}, {"2", "x"}},
+		},
+		{
+			name: "markup",
+			html: `<table><tr><td><script>alert(1)</script><i>a</i>  <b>b</b>
+				c</td></tr></table>`,
+			wantNames: []string{"c1"},
+			wantRows:  [][]any{{"a b c"}},
+		},
+		{
+			name: "nested",
+			html: `<table><tr><th>a</th></tr>` +
+				`<tr><td><table><tr><td>inner</td></tr></table></td></tr></table>`,
+			wantNames: []string{"a"},
+			wantRows:  [][]any{{"inner"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewHTMLReader(strings.NewReader(tt.html), NewReadOpts(InPreRead(100)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if names, _ := r.Names(); !reflect.DeepEqual(names, tt.wantNames) {
+				t.Errorf("NewHTMLReader().Names() = %v, want %v", names, tt.wantNames)
+			}
+			if got := r.PreReadRow(); !reflect.DeepEqual(got, tt.wantRows) {
+				t.Errorf("NewHTMLReader().PreReadRow() = %v, want %v", got, tt.wantRows)
+			}
+		})
+	}
+}
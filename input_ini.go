@@ -0,0 +1,77 @@
+package trdsql
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+// NewINIReader returns a ConfigReader of the INI file.
+//
+//	; comment
+//	global = value
+//	[section]
+//	key = value
+//	key2: "quoted value"
+//
+// The keys before the first section have no section.
+// All values are strings.
+func NewINIReader(reader io.Reader, opts *ReadOpts) (*ConfigReader, error) {
+	root, err := parseINI(reader)
+	if err != nil {
+		return nil, err
+	}
+	return newConfigReader(root, opts)
+}
+
+// parseINI parses the INI file into configTable.
+func parseINI(reader io.Reader) (*configTable, error) {
+	root := newConfigTable()
+	table := root
+	scanner := bufio.NewScanner(reader)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	first := true
+	for scanner.Scan() {
+		line := scanner.Text()
+		if first {
+			line = strings.TrimPrefix(line, "\ufeff")
+			first = false
+		}
+		line = strings.TrimSpace(line)
+		if line == "" || line[0] == ';' || line[0] == '#' {
+			continue
+		}
+		if line[0] == '[' && strings.HasSuffix(line, "]") {
+			name := strings.TrimSpace(line[1 : len(line)-1])
+			sub, ok := root.values[name].(*configTable)
+			if !ok {
+				sub = newConfigTable()
+				root.set(name, sub)
+			}
+			table = sub
+			continue
+		}
+		key, value := line, ""
+		if i := strings.IndexAny(line, "=:"); i >= 0 {
+			key, value = line[:i], line[i+1:]
+		}
+		table.set(strings.TrimSpace(key), iniValue(strings.TrimSpace(value)))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return root, nil
+}
+
+// iniValue removes the quotes or the inline comment(" ;" or " #") of the value.
+func iniValue(value string) string {
+	if len(value) >= 2 && (value[0] == '"' || value[0] == '\'') && value[len(value)-1] == value[0] {
+		return value[1 : len(value)-1]
+	}
+	for _, comment := range []string{" ;", " #", "\t;", "\t#"} {
+		if i := strings.Index(value, comment); i >= 0 {
+			value = strings.TrimSpace(value[:i])
+		}
+	}
+	return value
+}
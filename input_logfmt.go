@@ -0,0 +1,203 @@
+package trdsql
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// LogfmtReader parses logfmt (space-separated key=value pairs).
+//
+//	level=info msg="request done" path=/index.html status=200
+//
+// Quoted values can contain spaces and escapes(\" \\ \n \t...).
+// A key without a value (e.g. "debug") is an empty string,
+// and an empty value (e.g. "user=") is NULL, as written by LogfmtWriter.
+type LogfmtReader struct {
+	reader    *bufio.Reader
+	inNULL    string
+	preRead   []map[string]any
+	names     []string
+	types     []string
+	limitRead bool
+	needNULL  bool
+}
+
+// NewLogfmtReader returns a LogfmtReader configured with input options.
+func NewLogfmtReader(reader io.Reader, opts *ReadOpts) (*LogfmtReader, error) {
+	r := &LogfmtReader{}
+	r.reader = bufio.NewReader(reader)
+
+	if opts.InSkip > 0 {
+		skipRead(r, opts.InSkip)
+	}
+
+	r.limitRead = opts.InLimitRead
+
+	r.needNULL = opts.InNeedNULL
+	r.inNULL = opts.InNULL
+
+	names := map[string]bool{}
+	for range opts.InPreRead {
+		row, keys, err := r.read()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				return r, err
+			}
+			r.setColumnType()
+			debug.Print(err.Error())
+			return r, nil
+		}
+
+		// Add only unique column names.
+		for _, key := range keys {
+			if !names[key] {
+				names[key] = true
+				r.names = append(r.names, key)
+			}
+		}
+		r.preRead = append(r.preRead, row)
+	}
+	r.setColumnType()
+	return r, nil
+}
+
+func (r *LogfmtReader) setColumnType() {
+	if r.names == nil {
+		return
+	}
+	r.types = make([]string, len(r.names))
+	for i := range r.names {
+		r.types[i] = DefaultDBType
+	}
+}
+
+// Names returns column names.
+func (r *LogfmtReader) Names() ([]string, error) {
+	return r.names, nil
+}
+
+// Types returns column types.
+// All logfmt types return the DefaultDBType.
+func (r *LogfmtReader) Types() ([]string, error) {
+	return r.types, nil
+}
+
+// PreReadRow is returns only columns that store preread rows.
+func (r *LogfmtReader) PreReadRow() [][]any {
+	rowNum := len(r.preRead)
+	rows := make([][]any, rowNum)
+	for n := range rowNum {
+		rows[n] = make([]any, len(r.names))
+		for i, name := range r.names {
+			rows[n][i] = r.preRead[n][name]
+			if r.needNULL {
+				rows[n][i] = replaceNULL(r.inNULL, rows[n][i])
+			}
+		}
+	}
+	return rows
+}
+
+// ReadRow is read the rest of the row.
+// Keys that were not found in the preread rows are ignored.
+func (r *LogfmtReader) ReadRow(row []any) ([]any, error) {
+	if r.limitRead {
+		return nil, io.EOF
+	}
+
+	record, _, err := r.read()
+	if err != nil {
+		return row, err
+	}
+	for i, name := range r.names {
+		row[i] = record[name]
+		if r.needNULL {
+			row[i] = replaceNULL(r.inNULL, row[i])
+		}
+	}
+	return row, nil
+}
+
+func (r *LogfmtReader) read() (map[string]any, []string, error) {
+	for {
+		line, err := r.reader.ReadString('\n')
+		if line == "" && err != nil {
+			return nil, nil, err
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		return parseLogfmt(line)
+	}
+}
+
+// parseLogfmt parses a line of logfmt.
+// It returns the values and the keys in the order of appearance.
+// The value of "key=" is nil.
+func parseLogfmt(line string) (map[string]any, []string, error) {
+	kvs := make(map[string]any)
+	var keys []string
+	for i := 0; i < len(line); {
+		if line[i] == ' ' || line[i] == '\t' {
+			i++
+			continue
+		}
+		start := i
+		for i < len(line) && line[i] != '=' && line[i] != ' ' && line[i] != '\t' {
+			i++
+		}
+		key := line[start:i]
+		if key == "" {
+			return nil, nil, ErrInvalidColumn
+		}
+		var value any = ""
+		if i < len(line) && line[i] == '=' {
+			i++
+			switch {
+			case i == len(line) || line[i] == ' ' || line[i] == '\t':
+				value = nil
+			case line[i] == '"':
+				end := logfmtQuoteEnd(line, i)
+				if end < 0 {
+					return nil, nil, ErrInvalidColumn
+				}
+				quoted := line[i : end+1]
+				v, err := strconv.Unquote(quoted)
+				if err != nil {
+					v = quoted[1 : len(quoted)-1]
+				}
+				value = v
+				i = end + 1
+			default:
+				start := i
+				for i < len(line) && line[i] != ' ' && line[i] != '\t' {
+					i++
+				}
+				value = line[start:i]
+			}
+		}
+		if _, ok := kvs[key]; !ok {
+			keys = append(keys, key)
+		}
+		kvs[key] = value
+	}
+	return kvs, keys, nil
+}
+
+// logfmtQuoteEnd returns the index of the closing quote
+// of the quoted value starting at start, or -1.
+func logfmtQuoteEnd(line string, start int) int {
+	for i := start + 1; i < len(line); i++ {
+		switch line[i] {
+		case '\\':
+			i++
+		case '"':
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,123 @@
+package trdsql
+
+import (
+	"io"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewLogfmtReader(t *testing.T) {
+	type args struct {
+		reader io.Reader
+		opts   *ReadOpts
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    *LogfmtReader
+		wantErr bool
+	}{
+		{
+			name: "empty",
+			args: args{
+				reader: strings.NewReader(""),
+				opts:   NewReadOpts(),
+			},
+			want:    &LogfmtReader{},
+			wantErr: false,
+		},
+		{
+			name: "oneLine",
+			args: args{
+				reader: strings.NewReader(`id=1 name="test value"`),
+				opts:   NewReadOpts(),
+			},
+			want: &LogfmtReader{
+				names:   []string{"id", "name"},
+				types:   []string{"text", "text"},
+				preRead: []map[string]any{{"id": "1", "name": "test value"}},
+			},
+			wantErr: false,
+		},
+		{
+			name: "diffColumn",
+			args: args{
+				reader: strings.NewReader("id=1 name=test\nid=2 value=test flag"),
+				opts:   NewReadOpts(InPreRead(2)),
+			},
+			want: &LogfmtReader{
+				names: []string{"id", "name", "value", "flag"},
+				types: []string{"text", "text", "text", "text"},
+				preRead: []map[string]any{
+					{"id": "1", "name": "test"},
+					{"id": "2", "value": "test", "flag": ""},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "unterminatedQuote",
+			args: args{
+				reader: strings.NewReader(`id=1 name="test`),
+				opts:   NewReadOpts(),
+			},
+			want:    &LogfmtReader{},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NewLogfmtReader(tt.args.reader, tt.args.opts)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("NewLogfmtReader() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if !reflect.DeepEqual(got.names, tt.want.names) {
+				t.Errorf("NewLogfmtReader().names = %v, want %v", got.names, tt.want.names)
+			}
+			if !reflect.DeepEqual(got.types, tt.want.types) {
+				t.Errorf("NewLogfmtReader().types = %v, want %v", got.types, tt.want.types)
+			}
+			if !reflect.DeepEqual(got.preRead, tt.want.preRead) {
+				t.Errorf("NewLogfmtReader().preRead = %v, want %v", got.preRead, tt.want.preRead)
+			}
+		})
+	}
+}
+
+func TestLogfmtReader_ReadRow(t *testing.T) {
+	file, err := singleFileOpen(filepath.Join(dataDir, "test.logfmt"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	r, err := NewLogfmtReader(file, NewReadOpts(InPreRead(2)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantNames := []string{"ts", "level", "msg", "path", "status", "err", "debug"}
+	if names, _ := r.Names(); !reflect.DeepEqual(names, wantNames) {
+		t.Fatalf("LogfmtReader.Names() = %v, want %v", names, wantNames)
+	}
+	wantPreRead := [][]any{
+		{"2022-01-08T10:20:30Z", "info", "request done", "/index.html", "200", nil, nil},
+		{"2022-01-08T10:20:31Z", "error", "failed to \"connect\"\nretry", nil, nil, "dial tcp: timeout", ""},
+	}
+	if got := r.PreReadRow(); !reflect.DeepEqual(got, wantPreRead) {
+		t.Errorf("LogfmtReader.PreReadRow() = %v, want %v", got, wantPreRead)
+	}
+	row := make([]any, len(wantNames))
+	row, err = r.ReadRow(row)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []any{"2022-01-08T10:20:32Z", "info", nil, "/login", "302", nil, nil}
+	if !reflect.DeepEqual(row, want) {
+		t.Errorf("LogfmtReader.ReadRow() = %v, want %v", row, want)
+	}
+	if _, err := r.ReadRow(row); err != io.EOF {
+		t.Errorf("LogfmtReader.ReadRow() error = %v, want %v", err, io.EOF)
+	}
+}
@@ -0,0 +1,121 @@
+package trdsql
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/apache/arrow-go/v18/arrow/memory"
+	"github.com/apache/arrow-go/v18/parquet/file"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+)
+
+// parquetBatchSize is the number of rows read at a time from a row group.
+const parquetBatchSize = 1024
+
+// ParquetReader reads Apache Parquet files.
+// Column types are converted from the Parquet schema,
+// and rows are read in batches for each row group.
+type ParquetReader struct {
+	rows      arrowRows
+	inNULL    string
+	names     []string
+	types     []string
+	preRead   [][]any
+	limitRead bool
+	needNULL  bool
+}
+
+// NewParquetReader returns a ParquetReader configured with input options.
+func NewParquetReader(reader io.Reader, opts *ReadOpts) (*ParquetReader, error) {
+	r := &ParquetReader{}
+	ra, err := randomAccessReader(reader)
+	if err != nil {
+		return nil, err
+	}
+	pf, err := file.NewParquetReader(ra)
+	if err != nil {
+		return nil, fmt.Errorf("parquet: %w", err)
+	}
+	props := pqarrow.ArrowReadProperties{BatchSize: parquetBatchSize}
+	fr, err := pqarrow.NewFileReader(pf, props, memory.DefaultAllocator)
+	if err != nil {
+		return nil, fmt.Errorf("parquet: %w", err)
+	}
+	schema, err := fr.Schema()
+	if err != nil {
+		return nil, fmt.Errorf("parquet: %w", err)
+	}
+	r.names = make([]string, schema.NumFields())
+	r.types = make([]string, schema.NumFields())
+	for i, field := range schema.Fields() {
+		r.names[i] = field.Name
+		r.types[i] = arrowDBType(field.Type)
+	}
+
+	r.rows.records, err = fr.GetRecordReader(context.Background(), nil, nil)
+	if err != nil {
+		return nil, fmt.Errorf("parquet: %w", err)
+	}
+
+	if opts.InSkip > 0 {
+		skipRead(r, opts.InSkip)
+	}
+
+	r.limitRead = opts.InLimitRead
+	r.needNULL = opts.InNeedNULL
+	r.inNULL = opts.InNULL
+
+	for range opts.InPreRead {
+		row := make([]any, len(r.names))
+		row, err := r.read(row)
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				return r, err
+			}
+			debug.Print(err.Error())
+			return r, nil
+		}
+		r.preRead = append(r.preRead, row)
+	}
+	return r, nil
+}
+
+// Names returns column names.
+func (r *ParquetReader) Names() ([]string, error) {
+	return r.names, nil
+}
+
+// Types returns column types.
+// The types are converted from the Parquet physical and logical types.
+func (r *ParquetReader) Types() ([]string, error) {
+	return r.types, nil
+}
+
+// PreReadRow is returns only columns that store preread rows.
+func (r *ParquetReader) PreReadRow() [][]any {
+	return r.preRead
+}
+
+// ReadRow is read the rest of the row.
+func (r *ParquetReader) ReadRow(row []any) ([]any, error) {
+	if r.limitRead {
+		return nil, io.EOF
+	}
+	return r.read(row)
+}
+
+// read reads one row and replaces NULL.
+func (r *ParquetReader) read(row []any) ([]any, error) {
+	row, err := r.rows.read(row)
+	if err != nil {
+		return row, err
+	}
+	if r.needNULL {
+		for i := range row {
+			row[i] = replaceNULL(r.inNULL, row[i])
+		}
+	}
+	return row, nil
+}
@@ -0,0 +1,145 @@
+package trdsql
+
+import (
+	"io"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewParquetReader(t *testing.T) {
+	tests := []struct {
+		name        string
+		fileName    string
+		opts        *ReadOpts
+		wantNames   []string
+		wantTypes   []string
+		wantPreRead [][]any
+		wantErr     bool
+	}{
+		{
+			name:        "test.parquet",
+			fileName:    "test.parquet",
+			opts:        NewReadOpts(),
+			wantNames:   []string{"id", "name", "price", "day"},
+			wantTypes:   []string{"bigint", "text", "double precision", "date"},
+			wantPreRead: [][]any{{int64(1), "Orange", 50.5, "2022-01-08"}},
+			wantErr:     false,
+		},
+		{
+			name:      "preRead",
+			fileName:  "test.parquet",
+			opts:      NewReadOpts(InPreRead(3)),
+			wantNames: []string{"id", "name", "price", "day"},
+			wantTypes: []string{"bigint", "text", "double precision", "date"},
+			wantPreRead: [][]any{
+				{int64(1), "Orange", 50.5, "2022-01-08"},
+				{int64(2), "Melon", 500.0, "2022-01-09"},
+				{int64(3), "Apple", nil, "2022-01-10"},
+			},
+			wantErr: false,
+		},
+		{
+			name:        "skip",
+			fileName:    "test.parquet",
+			opts:        NewReadOpts(InSkip(2)),
+			wantNames:   []string{"id", "name", "price", "day"},
+			wantTypes:   []string{"bigint", "text", "double precision", "date"},
+			wantPreRead: [][]any{{int64(3), "Apple", nil, "2022-01-10"}},
+			wantErr:     false,
+		},
+		{
+			name:        "inNULL",
+			fileName:    "test.parquet",
+			opts:        NewReadOpts(InNeedNULL(true), InNULL("Orange")),
+			wantNames:   []string{"id", "name", "price", "day"},
+			wantTypes:   []string{"bigint", "text", "double precision", "date"},
+			wantPreRead: [][]any{{int64(1), nil, 50.5, "2022-01-08"}},
+			wantErr:     false,
+		},
+		{
+			name:     "notParquet",
+			fileName: "test.csv",
+			opts:     NewReadOpts(),
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := singleFileOpen(filepath.Join(dataDir, tt.fileName))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer file.Close()
+			got, err := NewParquetReader(file, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewParquetReader() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got.names, tt.wantNames) {
+				t.Errorf("NewParquetReader().names = %v, want %v", got.names, tt.wantNames)
+			}
+			if !reflect.DeepEqual(got.types, tt.wantTypes) {
+				t.Errorf("NewParquetReader().types = %v, want %v", got.types, tt.wantTypes)
+			}
+			if !reflect.DeepEqual(got.PreReadRow(), tt.wantPreRead) {
+				t.Errorf("NewParquetReader().PreReadRow() = %v, want %v", got.PreReadRow(), tt.wantPreRead)
+			}
+		})
+	}
+}
+
+func TestParquetReader_ReadRow(t *testing.T) {
+	// test.parquet has two row groups (2 rows + 1 row).
+	file, err := singleFileOpen(filepath.Join(dataDir, "test.parquet"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	r, err := NewParquetReader(file, NewReadOpts())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]any{
+		{int64(2), "Melon", 500.0, "2022-01-09"},
+		{int64(3), "Apple", nil, "2022-01-10"},
+	}
+	for _, w := range want {
+		row := make([]any, len(r.names))
+		got, err := r.ReadRow(row)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, w) {
+			t.Errorf("ParquetReader.ReadRow() = %v, want %v", got, w)
+		}
+	}
+	row := make([]any, len(r.names))
+	if _, err := r.ReadRow(row); err != io.EOF {
+		t.Errorf("ParquetReader.ReadRow() error = %v, want %v", err, io.EOF)
+	}
+}
+
+func TestParquetReaderStream(t *testing.T) {
+	// A non-file reader is read into memory.
+	file, err := singleFileOpen(filepath.Join(dataDir, "test.parquet"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	b, err := io.ReadAll(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	r, err := NewParquetReader(strings.NewReader(string(b)), NewReadOpts())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"id", "name", "price", "day"}
+	if got, _ := r.Names(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParquetReader.Names() = %v, want %v", got, want)
+	}
+}
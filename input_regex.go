@@ -0,0 +1,199 @@
+package trdsql
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrNoPattern is returned when the regular expression is not specified.
+var ErrNoPattern = errors.New("no regular expression")
+
+// regexRejectColumn is the name of the column that stores unmatched lines.
+const regexRejectColumn = "_reject"
+
+// RegexReader reads lines that match a regular expression.
+// The named capture groups of the regular expression become columns.
+// Grok-style patterns (%{IPORHOST:client} %{INT:status:int}) can be used in the expression.
+// If there are no named groups, the unnamed groups become columns c1, c2...
+//
+// Lines that do not match are skipped and counted,
+// or stored in the _reject column with InRegexReject.
+type RegexReader struct {
+	reader    *bufio.Reader
+	regexp    *regexp.Regexp
+	groups    [][]int
+	inNULL    string
+	names     []string
+	types     []string
+	preRead   [][]any
+	rejected  int
+	reject    bool
+	limitRead bool
+	needNULL  bool
+}
+
+// NewRegexReader returns a RegexReader configured with input options.
+func NewRegexReader(reader io.Reader, opts *ReadOpts) (*RegexReader, error) {
+	r := &RegexReader{}
+	r.reader = bufio.NewReader(reader)
+	if opts.InRegex == "" {
+		return nil, ErrNoPattern
+	}
+	expr, fields, err := expandGrok(opts.InRegex)
+	if err != nil {
+		return nil, err
+	}
+	r.regexp, err = regexp.Compile(expr)
+	if err != nil {
+		return nil, fmt.Errorf("regex: %w", err)
+	}
+	r.setColumns(fields)
+
+	r.reject = opts.InRegexReject
+	if r.reject {
+		r.names = append(r.names, regexRejectColumn)
+		r.types = append(r.types, DefaultDBType)
+	}
+
+	for range opts.InSkip {
+		if _, err := r.readLine(); err != nil {
+			break
+		}
+	}
+
+	r.limitRead = opts.InLimitRead
+	r.needNULL = opts.InNeedNULL
+	r.inNULL = opts.InNULL
+
+	for range opts.InPreRead {
+		row := make([]any, len(r.names))
+		row, err := r.read(row)
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				return r, err
+			}
+			debug.Print(err.Error())
+			return r, nil
+		}
+		r.preRead = append(r.preRead, row)
+	}
+	return r, nil
+}
+
+// setColumns sets the columns from the capture groups.
+// Groups with the same name are one column, and the first matched group is the value.
+func (r *RegexReader) setColumns(fields map[string]grokField) {
+	subNames := r.regexp.SubexpNames()
+	named := false
+	for _, name := range subNames {
+		if name != "" {
+			named = true
+			break
+		}
+	}
+	index := make(map[string]int)
+	for i := 1; i < len(subNames); i++ {
+		name, dbType := subNames[i], DefaultDBType
+		if f, ok := fields[name]; ok {
+			name, dbType = f.name, f.dbType
+		}
+		if !named {
+			name = "c" + strconv.Itoa(i)
+		}
+		if name == "" {
+			continue
+		}
+		if n, ok := index[name]; ok {
+			r.groups[n] = append(r.groups[n], i)
+			continue
+		}
+		index[name] = len(r.names)
+		r.names = append(r.names, name)
+		r.types = append(r.types, dbType)
+		r.groups = append(r.groups, []int{i})
+	}
+}
+
+// Names returns column names.
+func (r *RegexReader) Names() ([]string, error) {
+	return r.names, nil
+}
+
+// Types returns column types.
+// The types are DefaultDBType except for the grok fields with a type(%{INT:status:int}).
+func (r *RegexReader) Types() ([]string, error) {
+	return r.types, nil
+}
+
+// PreReadRow is returns only columns that store preread rows.
+func (r *RegexReader) PreReadRow() [][]any {
+	return r.preRead
+}
+
+// ReadRow is read the rest of the row.
+func (r *RegexReader) ReadRow(row []any) ([]any, error) {
+	if r.limitRead {
+		return nil, io.EOF
+	}
+	return r.read(row)
+}
+
+func (r *RegexReader) read(row []any) ([]any, error) {
+	for {
+		line, err := r.readLine()
+		if err != nil {
+			if errors.Is(err, io.EOF) && r.rejected > 0 {
+				log.Printf("WARN: regex: %d line(s) did not match", r.rejected)
+				r.rejected = 0
+			}
+			return row, err
+		}
+		if line == "" {
+			continue
+		}
+		loc := r.regexp.FindStringSubmatchIndex(line)
+		if loc == nil {
+			if !r.reject {
+				debug.Printf("regex: unmatched line: %s", line)
+				r.rejected++
+				continue
+			}
+			for i := range row {
+				row[i] = nil
+			}
+			row[len(row)-1] = line
+			return row, nil
+		}
+		for i := 0; i < len(row) && i < len(r.groups); i++ {
+			row[i] = nil
+			for _, g := range r.groups[i] {
+				if loc[2*g] >= 0 {
+					row[i] = line[loc[2*g]:loc[2*g+1]]
+					break
+				}
+			}
+			if r.needNULL {
+				row[i] = replaceNULL(r.inNULL, row[i])
+			}
+		}
+		if r.reject && len(row) > len(r.groups) {
+			row[len(r.groups)] = nil
+		}
+		return row, nil
+	}
+}
+
+// readLine reads a line without the line terminator.
+func (r *RegexReader) readLine() (string, error) {
+	line, err := r.reader.ReadString('\n')
+	if line == "" && err != nil {
+		return "", err
+	}
+	return strings.TrimRight(line, "\r\n"), nil
+}
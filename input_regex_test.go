@@ -0,0 +1,169 @@
+package trdsql
+
+import (
+	"errors"
+	"io"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewRegexReader(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        *ReadOpts
+		wantNames   []string
+		wantTypes   []string
+		wantPreRead [][]any
+		wantErr     error
+	}{
+		{
+			name:        "namedGroups",
+			opts:        NewReadOpts(InRegex(`^(?P<ip>\S+) .*" (?P<status>\d+) (?P<size>\d+|-)`), InPreRead(3)),
+			wantNames:   []string{"ip", "status", "size"},
+			wantTypes:   []string{"text", "text", "text"},
+			wantPreRead: [][]any{{"127.0.0.1", "200", "2326"}, {"::1", "302", "-"}, {"192.168.0.1", "404", "512"}},
+		},
+		{
+			name:        "unnamedGroups",
+			opts:        NewReadOpts(InRegex(`^(\S+) \S+ (\S+)`)),
+			wantNames:   []string{"c1", "c2"},
+			wantTypes:   []string{"text", "text"},
+			wantPreRead: [][]any{{"127.0.0.1", "frank"}},
+		},
+		{
+			name:        "grok",
+			opts:        NewReadOpts(InRegex(`^%{IPORHOST:client.ip} .*\[%{HTTPDATE:time}\] "%{WORD:method} %{URIPATHPARAM:path} [^"]*" %{INT:status:int}`)),
+			wantNames:   []string{"client.ip", "time", "method", "path", "status"},
+			wantTypes:   []string{"text", "text", "text", "text", "bigint"},
+			wantPreRead: [][]any{{"127.0.0.1", "10/Oct/2000:13:55:36 -0700", "GET", "/apache_pb.gif", "200"}},
+		},
+		{
+			name:        "sameName",
+			opts:        NewReadOpts(InRegex(`"(?:GET (?P<path>\S+)|POST (?P<path>\S+))`), InPreRead(2)),
+			wantNames:   []string{"path"},
+			wantTypes:   []string{"text"},
+			wantPreRead: [][]any{{"/apache_pb.gif"}, {"/login"}},
+		},
+		{
+			name:        "reject",
+			opts:        NewReadOpts(InRegex(`^%{IP:ip} `), InRegexReject(true), InPreRead(4)),
+			wantNames:   []string{"ip", "_reject"},
+			wantTypes:   []string{"text", "text"},
+			wantPreRead: [][]any{{"127.0.0.1", nil}, {"::1", nil}, {nil, "garbage line"}, {"192.168.0.1", nil}},
+		},
+		{
+			name:        "skip",
+			opts:        NewReadOpts(InRegex(`^(?P<ip>\S+) `), InSkip(1), InPreRead(3)),
+			wantNames:   []string{"ip"},
+			wantTypes:   []string{"text"},
+			wantPreRead: [][]any{{"::1"}, {"garbage"}, {"192.168.0.1"}},
+		},
+		{
+			name:    "noPattern",
+			opts:    NewReadOpts(),
+			wantErr: ErrNoPattern,
+		},
+		{
+			name:    "unknownPattern",
+			opts:    NewReadOpts(InRegex(`%{NOTHING:x}`)),
+			wantErr: ErrUnknownPattern,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := singleFileOpen(filepath.Join(dataDir, "access.log"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer file.Close()
+			got, err := NewRegexReader(file, tt.opts)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewRegexReader() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			if !reflect.DeepEqual(got.names, tt.wantNames) {
+				t.Errorf("NewRegexReader().names = %v, want %v", got.names, tt.wantNames)
+			}
+			if !reflect.DeepEqual(got.types, tt.wantTypes) {
+				t.Errorf("NewRegexReader().types = %v, want %v", got.types, tt.wantTypes)
+			}
+			if !reflect.DeepEqual(got.PreReadRow(), tt.wantPreRead) {
+				t.Errorf("NewRegexReader().PreReadRow() = %v, want %v", got.PreReadRow(), tt.wantPreRead)
+			}
+		})
+	}
+}
+
+func TestRegexReader_ReadRow(t *testing.T) {
+	r, err := NewRegexReader(strings.NewReader("a=1\nb\n\nc=\n"), NewReadOpts(InRegex(`^(?P<key>\w+)=(?P<value>\w*)(?P<opt>!)?`), InPreRead(0)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]any{{"a", "1", nil}, {"c", "", nil}}
+	for _, w := range want {
+		row := make([]any, len(r.names))
+		got, err := r.ReadRow(row)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, w) {
+			t.Errorf("RegexReader.ReadRow() = %v, want %v", got, w)
+		}
+	}
+	row := make([]any, len(r.names))
+	if _, err := r.ReadRow(row); !errors.Is(err, io.EOF) {
+		t.Errorf("RegexReader.ReadRow() error = %v, want %v", err, io.EOF)
+	}
+}
+
+func TestExpandGrok(t *testing.T) {
+	tests := []struct {
+		pattern string
+		input   string
+		want    map[string]string
+	}{
+		{
+			pattern: `%{COMBINEDAPACHELOG}`,
+			input:   `::1 - - [10/Oct/2000:13:55:37 -0700] "POST /login HTTP/1.1" 302 - "-" "curl/7.68.0"`,
+			want: map[string]string{
+				"clientip": "::1", "verb": "POST", "request": "/login", "httpversion": "1.1",
+				"response": "302", "referrer": `"-"`, "agent": `"curl/7.68.0"`,
+			},
+		},
+		{
+			pattern: `%{TIMESTAMP_ISO8601:ts} %{LOGLEVEL:level} %{GREEDYDATA:msg}`,
+			input:   `2022-01-08T10:20:30.123+09:00 WARN disk "full"`,
+			want:    map[string]string{"ts": "2022-01-08T10:20:30.123+09:00", "level": "WARN", "msg": `disk "full"`},
+		},
+		{
+			pattern: `%{SYSLOGTIMESTAMP:ts} %{HOSTNAME:host} %{UUID:id} %{QS:q}`,
+			input:   `Jan  8 10:20:30 web-01.example.com 123e4567-e89b-12d3-a456-426614174000 'a \' b'`,
+			want:    map[string]string{"ts": "Jan  8 10:20:30", "host": "web-01.example.com", "id": "123e4567-e89b-12d3-a456-426614174000", "q": `'a \' b'`},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.pattern, func(t *testing.T) {
+			r, err := NewRegexReader(strings.NewReader(tt.input), NewReadOpts(InRegex(tt.pattern)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			rows := r.PreReadRow()
+			if len(rows) != 1 {
+				t.Fatalf("expandGrok() did not match %s", tt.input)
+			}
+			for i, name := range r.names {
+				want, ok := tt.want[name]
+				if !ok {
+					continue
+				}
+				if rows[0][i] != want {
+					t.Errorf("expandGrok() %s = %v, want %v", name, rows[0][i], want)
+				}
+			}
+		})
+	}
+}
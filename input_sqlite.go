@@ -0,0 +1,267 @@
+package trdsql
+
+import (
+	"bytes"
+	"database/sql"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+)
+
+// ErrNoTable is returned when the specified table does not exist.
+var ErrNoTable = errors.New("no such table")
+
+// sqliteMagic is the header string of the SQLite database file.
+var sqliteMagic = []byte("SQLite format 3\x00")
+
+// SQLiteReader reads a table of an SQLite database file.
+// The table is specified by InSelector (data.db::table).
+// If it is not specified, the first table is read.
+// The file is opened read-only with the SQLite driver
+// regardless of the database used for the query.
+type SQLiteReader struct {
+	db        *sql.DB
+	rows      *sql.Rows
+	temp      string
+	inNULL    string
+	names     []string
+	types     []string
+	preRead   [][]any
+	scan      []any
+	limitRead bool
+	needNULL  bool
+}
+
+// NewSQLiteReader returns a SQLiteReader configured with input options.
+func NewSQLiteReader(reader io.Reader, opts *ReadOpts) (*SQLiteReader, error) {
+	r := &SQLiteReader{}
+	fileName, err := r.dbFile(reader)
+	if err != nil {
+		return nil, err
+	}
+	dsn := "file:" + (&url.URL{Path: fileName}).EscapedPath() + "?mode=ro"
+	db, err := sql.Open(DefaultDriver, dsn)
+	if err != nil {
+		r.close()
+		return nil, fmt.Errorf("sqlite: %w", err)
+	}
+	r.db = db
+
+	table, err := r.table(trimQuoteAll(opts.InSelector))
+	if err != nil {
+		r.close()
+		return nil, err
+	}
+	query := `SELECT * FROM "` + strings.ReplaceAll(table, `"`, `""`) + `"`
+	rows, err := r.db.Query(query)
+	if err != nil {
+		r.close()
+		return nil, fmt.Errorf("sqlite: %w", err)
+	}
+	r.rows = rows
+
+	columnTypes, err := rows.ColumnTypes()
+	if err != nil {
+		r.close()
+		return nil, fmt.Errorf("sqlite: %w", err)
+	}
+	r.names = make([]string, len(columnTypes))
+	r.types = make([]string, len(columnTypes))
+	for i, ct := range columnTypes {
+		r.names[i] = ct.Name()
+		r.types[i] = sqliteDBType(ct.DatabaseTypeName())
+	}
+	r.scan = make([]any, len(r.names))
+
+	if opts.InSkip > 0 {
+		skipRead(r, opts.InSkip)
+	}
+
+	r.limitRead = opts.InLimitRead
+	r.needNULL = opts.InNeedNULL
+	r.inNULL = opts.InNULL
+
+	for range opts.InPreRead {
+		row := make([]any, len(r.names))
+		row, err := r.read(row)
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				return r, err
+			}
+			debug.Print(err.Error())
+			return r, nil
+		}
+		r.preRead = append(r.preRead, row)
+	}
+	return r, nil
+}
+
+// dbFile returns the name of the database file.
+// Readers other than files (stdin, compressed files)
+// are written to a temporary file because SQLite requires a file.
+func (r *SQLiteReader) dbFile(reader io.Reader) (string, error) {
+	if f, ok := reader.(*os.File); ok && f != os.Stdin {
+		return f.Name(), nil
+	}
+	temp, err := os.CreateTemp("", "trdsql-*.db")
+	if err != nil {
+		return "", err
+	}
+	r.temp = temp.Name()
+	if _, err := io.Copy(temp, reader); err != nil {
+		temp.Close()
+		r.close()
+		return "", err
+	}
+	if err := temp.Close(); err != nil {
+		r.close()
+		return "", err
+	}
+	return r.temp, nil
+}
+
+// table returns the name of the table to read.
+// Views can also be specified.
+func (r *SQLiteReader) table(name string) (string, error) {
+	query := "SELECT name FROM sqlite_master WHERE type IN ('table', 'view') AND name NOT LIKE 'sqlite\\_%' ESCAPE '\\'"
+	var args []any
+	if name != "" {
+		query += " AND name = ?"
+		args = append(args, name)
+	}
+	query += " ORDER BY rowid LIMIT 1"
+	var table string
+	if err := r.db.QueryRow(query, args...).Scan(&table); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return "", fmt.Errorf("sqlite: %w: %s", ErrNoTable, name)
+		}
+		return "", fmt.Errorf("sqlite: %w", err)
+	}
+	return table, nil
+}
+
+// sqliteDBType returns the database type from the declared type of SQLite.
+// It follows the type affinity rules of SQLite.
+func sqliteDBType(declType string) string {
+	t := strings.ToUpper(declType)
+	switch {
+	case t == "":
+		return DefaultDBType
+	case strings.Contains(t, "INT"):
+		return "bigint"
+	case strings.Contains(t, "CHAR"), strings.Contains(t, "CLOB"), strings.Contains(t, "TEXT"), strings.Contains(t, "BLOB"):
+		return DefaultDBType
+	case strings.Contains(t, "REAL"), strings.Contains(t, "FLOA"), strings.Contains(t, "DOUB"):
+		return "double precision"
+	case t == "DATE":
+		return "date"
+	case strings.Contains(t, "TIME"):
+		return "timestamp"
+	default:
+		return "numeric"
+	}
+}
+
+// isSQLiteFile returns true if the file has the header of the SQLite database.
+func isSQLiteFile(fileName string) bool {
+	f, err := os.Open(expandTilde(trimQuote(fileName)))
+	if err != nil {
+		return false
+	}
+	defer f.Close()
+	buf := make([]byte, len(sqliteMagic))
+	if _, err := io.ReadFull(f, buf); err != nil {
+		return false
+	}
+	return bytes.Equal(buf, sqliteMagic)
+}
+
+// Names returns column names.
+func (r *SQLiteReader) Names() ([]string, error) {
+	return r.names, nil
+}
+
+// Types returns column types.
+// The types are converted from the declared types of the table.
+func (r *SQLiteReader) Types() ([]string, error) {
+	return r.types, nil
+}
+
+// PreReadRow is returns only columns that store preread rows.
+func (r *SQLiteReader) PreReadRow() [][]any {
+	return r.preRead
+}
+
+// ReadRow is read the rest of the row.
+func (r *SQLiteReader) ReadRow(row []any) ([]any, error) {
+	if r.limitRead {
+		r.close()
+		return nil, io.EOF
+	}
+	return r.read(row)
+}
+
+func (r *SQLiteReader) read(row []any) ([]any, error) {
+	if r.rows == nil {
+		return row, io.EOF
+	}
+	if !r.rows.Next() {
+		err := r.rows.Err()
+		r.close()
+		if err != nil {
+			return row, fmt.Errorf("sqlite: %w", err)
+		}
+		return row, io.EOF
+	}
+	values := make([]any, len(r.names))
+	for i := range values {
+		r.scan[i] = &values[i]
+	}
+	if err := r.rows.Scan(r.scan...); err != nil {
+		r.close()
+		return row, fmt.Errorf("sqlite: %w", err)
+	}
+	for i := 0; i < len(row) && i < len(values); i++ {
+		switch v := values[i].(type) {
+		case []byte:
+			row[i] = ValString(v)
+		case time.Time:
+			row[i] = v
+			if r.types[i] == "date" {
+				row[i] = v.Format(time.DateOnly)
+			}
+		default:
+			row[i] = v
+		}
+		if r.needNULL {
+			row[i] = replaceNULL(r.inNULL, row[i])
+		}
+	}
+	return row, nil
+}
+
+// close closes the database and removes the temporary file.
+func (r *SQLiteReader) close() {
+	if r.rows != nil {
+		if err := r.rows.Close(); err != nil {
+			debug.Printf("sqlite: %s", err)
+		}
+		r.rows = nil
+	}
+	if r.db != nil {
+		if err := r.db.Close(); err != nil {
+			debug.Printf("sqlite: %s", err)
+		}
+		r.db = nil
+	}
+	if r.temp != "" {
+		if err := os.Remove(r.temp); err != nil {
+			debug.Printf("sqlite: %s", err)
+		}
+		r.temp = ""
+	}
+}
@@ -0,0 +1,138 @@
+package trdsql
+
+import (
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+func TestNewSQLiteReader(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        *ReadOpts
+		wantNames   []string
+		wantTypes   []string
+		wantPreRead [][]any
+		wantErr     error
+	}{
+		{
+			name:        "firstTable",
+			opts:        NewReadOpts(),
+			wantNames:   []string{"id", "name", "price", "day"},
+			wantTypes:   []string{"bigint", "text", "double precision", "date"},
+			wantPreRead: [][]any{{int64(1), "Orange", 50.5, "2022-01-08"}},
+		},
+		{
+			name:        "table",
+			opts:        NewReadOpts(InSelector("note"), InPreRead(2)),
+			wantNames:   []string{"a", "b"},
+			wantTypes:   []string{"text", "text"},
+			wantPreRead: [][]any{{"x", "y"}, {int64(1), nil}},
+		},
+		{
+			name:        "view",
+			opts:        NewReadOpts(InSelector("cheap")),
+			wantNames:   []string{"id", "name"},
+			wantTypes:   []string{"bigint", "text"},
+			wantPreRead: [][]any{{int64(1), "Orange"}},
+		},
+		{
+			name:        "skip",
+			opts:        NewReadOpts(InSkip(2)),
+			wantNames:   []string{"id", "name", "price", "day"},
+			wantTypes:   []string{"bigint", "text", "double precision", "date"},
+			wantPreRead: [][]any{{int64(3), "Apple", nil, "2022-01-10"}},
+		},
+		{
+			name:        "inNULL",
+			opts:        NewReadOpts(InNeedNULL(true), InNULL("Orange")),
+			wantNames:   []string{"id", "name", "price", "day"},
+			wantTypes:   []string{"bigint", "text", "double precision", "date"},
+			wantPreRead: [][]any{{int64(1), nil, 50.5, "2022-01-08"}},
+		},
+		{
+			name:    "noTable",
+			opts:    NewReadOpts(InSelector("nothing")),
+			wantErr: ErrNoTable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := singleFileOpen(filepath.Join(dataDir, "test.db"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer file.Close()
+			got, err := NewSQLiteReader(file, tt.opts)
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("NewSQLiteReader() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr != nil {
+				return
+			}
+			defer got.close()
+			if !reflect.DeepEqual(got.names, tt.wantNames) {
+				t.Errorf("NewSQLiteReader().names = %v, want %v", got.names, tt.wantNames)
+			}
+			if !reflect.DeepEqual(got.types, tt.wantTypes) {
+				t.Errorf("NewSQLiteReader().types = %v, want %v", got.types, tt.wantTypes)
+			}
+			if !reflect.DeepEqual(got.PreReadRow(), tt.wantPreRead) {
+				t.Errorf("NewSQLiteReader().PreReadRow() = %v, want %v", got.PreReadRow(), tt.wantPreRead)
+			}
+		})
+	}
+}
+
+func TestSQLiteReaderCompressed(t *testing.T) {
+	file, err := singleFileOpen(filepath.Join(dataDir, "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	// A reader other than a file is copied to a temporary file.
+	r, err := NewSQLiteReader(io.NopCloser(file), NewReadOpts(InPreRead(0)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	temp := r.temp
+	if temp == "" {
+		t.Fatal("NewSQLiteReader() did not create a temporary file")
+	}
+	row := make([]any, len(r.names))
+	for {
+		if _, err := r.ReadRow(row); err != nil {
+			if !errors.Is(err, io.EOF) {
+				t.Fatal(err)
+			}
+			break
+		}
+	}
+	if _, err := os.Stat(temp); !os.IsNotExist(err) {
+		t.Errorf("temporary file %s was not removed", temp)
+	}
+}
+
+func TestGuessOptsSQLiteMagic(t *testing.T) {
+	b, err := os.ReadFile(filepath.Join(dataDir, "test.db"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	fileName := filepath.Join(t.TempDir(), "data")
+	if err := os.WriteFile(fileName, b, 0o600); err != nil {
+		t.Fatal(err)
+	}
+	got, gotFileName := GuessOpts(NewReadOpts(), fileName+"::note")
+	if gotFileName != fileName {
+		t.Errorf("GuessOpts() fileName = %v, want %v", gotFileName, fileName)
+	}
+	if got.realFormat != SQLITE {
+		t.Errorf("GuessOpts() realFormat = %v, want %v", got.realFormat, SQLITE)
+	}
+	if got.InSelector != "note" {
+		t.Errorf("GuessOpts() InSelector = %v, want %v", got.InSelector, "note")
+	}
+}
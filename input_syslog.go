@@ -0,0 +1,292 @@
+package trdsql
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"log"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// syslogNames is the column names of the syslog.
+var syslogNames = []string{
+	"priority", "facility", "severity", "timestamp", "hostname",
+	"app_name", "procid", "msgid", "structured_data", "message",
+}
+
+// syslogTypes is the column types of the syslog.
+var syslogTypes = []string{
+	"int", "text", "text", "timestamp", "text",
+	"text", "text", "text", "text", "text",
+}
+
+// syslogFacilities is the names of the facilities.
+var syslogFacilities = []string{
+	"kern", "user", "mail", "daemon", "auth", "syslog", "lpr", "news",
+	"uucp", "cron", "authpriv", "ftp", "ntp", "security", "console", "solaris-cron",
+	"local0", "local1", "local2", "local3", "local4", "local5", "local6", "local7",
+}
+
+// syslogSeverities is the names of the severities.
+var syslogSeverities = []string{
+	"emerg", "alert", "crit", "err", "warning", "notice", "info", "debug",
+}
+
+// syslogStampLayout is the layout of the timestamp of RFC 3164, which has no year.
+const syslogStampLayout = "Jan _2 15:04:05"
+
+// SyslogReader reads the syslog of RFC 5424 and RFC 3164(BSD syslog).
+//
+//	<34>1 2003-10-11T22:14:15.003Z mymachine.example.com su - ID47 - 'su root' failed
+//	<34>Oct 11 22:14:15 mymachine su[123]: 'su root' failed
+//
+// The <PRI> part is optional, so files such as /var/log/messages can also be read
+// (priority, facility and severity are NULL).
+// The timestamp of RFC 3164 has no year, so it is assumed to be within the last year.
+// "-" (NILVALUE) of RFC 5424 is NULL.
+type SyslogReader struct {
+	reader    *bufio.Reader
+	now       time.Time
+	inNULL    string
+	preRead   [][]any
+	rejected  int
+	limitRead bool
+	needNULL  bool
+}
+
+// NewSyslogReader returns a SyslogReader configured with input options.
+func NewSyslogReader(reader io.Reader, opts *ReadOpts) (*SyslogReader, error) {
+	r := &SyslogReader{}
+	r.reader = bufio.NewReader(reader)
+	r.now = time.Now()
+
+	if opts.InSkip > 0 {
+		skipRead(r, opts.InSkip)
+	}
+
+	r.limitRead = opts.InLimitRead
+	r.needNULL = opts.InNeedNULL
+	r.inNULL = opts.InNULL
+
+	for range opts.InPreRead {
+		row := make([]any, len(syslogNames))
+		row, err := r.read(row)
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				return r, err
+			}
+			debug.Print(err.Error())
+			return r, nil
+		}
+		r.preRead = append(r.preRead, row)
+	}
+	return r, nil
+}
+
+// Names returns column names.
+func (r *SyslogReader) Names() ([]string, error) {
+	return syslogNames, nil
+}
+
+// Types returns column types.
+func (r *SyslogReader) Types() ([]string, error) {
+	return syslogTypes, nil
+}
+
+// PreReadRow is returns only columns that store preread rows.
+func (r *SyslogReader) PreReadRow() [][]any {
+	return r.preRead
+}
+
+// ReadRow is read the rest of the row.
+// Lines that cannot be parsed as syslog are skipped.
+func (r *SyslogReader) ReadRow(row []any) ([]any, error) {
+	if r.limitRead {
+		return nil, io.EOF
+	}
+	return r.read(row)
+}
+
+func (r *SyslogReader) read(row []any) ([]any, error) {
+	for {
+		line, err := r.reader.ReadString('\n')
+		if line == "" && err != nil {
+			if errors.Is(err, io.EOF) && r.rejected > 0 {
+				log.Printf("WARN: syslog: %d line(s) did not match", r.rejected)
+				r.rejected = 0
+			}
+			return row, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		values, ok := parseSyslog(line, r.now)
+		if !ok {
+			debug.Printf("syslog: unmatched line: %s", line)
+			r.rejected++
+			continue
+		}
+		for i := 0; i < len(row) && i < len(values); i++ {
+			row[i] = values[i]
+			if r.needNULL {
+				row[i] = replaceNULL(r.inNULL, row[i])
+			}
+		}
+		return row, nil
+	}
+}
+
+// parseSyslog parses a line of syslog into the values of the columns.
+// now is used to complete the year of the RFC 3164 timestamp.
+func parseSyslog(line string, now time.Time) ([]any, bool) {
+	values := make([]any, len(syslogNames))
+	rest := line
+	if strings.HasPrefix(rest, "<") {
+		end := strings.IndexByte(rest, '>')
+		if end < 2 || end > 4 {
+			return nil, false
+		}
+		pri, err := strconv.Atoi(rest[1:end])
+		if err != nil || pri < 0 || pri > 191 {
+			return nil, false
+		}
+		values[0] = pri
+		values[1] = syslogFacility(pri >> 3)
+		values[2] = syslogSeverities[pri&7]
+		rest = rest[end+1:]
+	}
+	if len(rest) > 2 && rest[0] >= '1' && rest[0] <= '9' && rest[1] == ' ' {
+		if values[0] == nil {
+			return nil, false
+		}
+		return values, parse5424(rest[2:], values)
+	}
+	return values, parse3164(rest, values, now)
+}
+
+// syslogFacility returns the name of the facility.
+func syslogFacility(f int) any {
+	if f < len(syslogFacilities) {
+		return syslogFacilities[f]
+	}
+	return strconv.Itoa(f)
+}
+
+// parse5424 parses the part after the version of RFC 5424.
+//
+//	TIMESTAMP HOSTNAME APP-NAME PROCID MSGID STRUCTURED-DATA [MSG]
+func parse5424(s string, values []any) bool {
+	for i := 3; i <= 7; i++ {
+		field, rest, ok := strings.Cut(s, " ")
+		if !ok && i < 7 {
+			return false
+		}
+		s = rest
+		if i == 3 && field != "-" {
+			t, err := time.Parse(time.RFC3339Nano, field)
+			if err != nil {
+				return false
+			}
+			values[i] = t
+			continue
+		}
+		values[i] = logField(field)
+	}
+	sd, msg, ok := cutStructuredData(s)
+	if !ok {
+		return false
+	}
+	values[8] = logField(sd)
+	if msg != "" {
+		values[9] = strings.TrimPrefix(msg, "\ufeff")
+	}
+	return true
+}
+
+// cutStructuredData cuts the STRUCTURED-DATA from the beginning of s.
+// STRUCTURED-DATA is "-" or one or more [SD-ID PARAM="VALUE"...],
+// and "]", "\"" and "\\" are escaped with "\\" in the value.
+func cutStructuredData(s string) (string, string, bool) {
+	if s == "-" || strings.HasPrefix(s, "- ") {
+		return "-", strings.TrimPrefix(s[1:], " "), true
+	}
+	i := 0
+	for i < len(s) && s[i] == '[' {
+		quoted := false
+		for i++; i < len(s); i++ {
+			c := s[i]
+			if quoted && c == '\\' {
+				i++
+				continue
+			}
+			if c == '"' {
+				quoted = !quoted
+				continue
+			}
+			if !quoted && c == ']' {
+				break
+			}
+		}
+		if i >= len(s) {
+			return "", "", false
+		}
+		i++
+	}
+	if i == 0 {
+		return "", "", false
+	}
+	return s[:i], strings.TrimPrefix(s[i:], " "), true
+}
+
+// parse3164 parses the part after the <PRI> of RFC 3164.
+//
+//	Mmm dd hh:mm:ss HOSTNAME TAG[PID]: MSG
+//
+// An RFC 3339 timestamp (the high precision format of rsyslog) is also accepted.
+func parse3164(s string, values []any, now time.Time) bool {
+	if len(s) >= len(syslogStampLayout) {
+		if t, err := time.ParseInLocation(syslogStampLayout, s[:len(syslogStampLayout)], now.Location()); err == nil {
+			values[3] = syslogYear(t, now)
+			s = s[len(syslogStampLayout):]
+		}
+	}
+	if values[3] == nil {
+		field, rest, _ := strings.Cut(s, " ")
+		t, err := time.Parse(time.RFC3339Nano, field)
+		if err != nil {
+			return false
+		}
+		values[3] = t
+		s = rest
+	}
+	s = strings.TrimPrefix(s, " ")
+	hostname, s, _ := strings.Cut(s, " ")
+	if hostname == "" {
+		return false
+	}
+	values[4] = hostname
+	if tag, msg, ok := strings.Cut(s, ": "); ok && !strings.Contains(tag, " ") {
+		if app, pid, ok := strings.Cut(tag, "["); ok && strings.HasSuffix(pid, "]") {
+			values[5], values[6] = app, strings.TrimSuffix(pid, "]")
+		} else {
+			values[5] = tag
+		}
+		s = msg
+	}
+	values[9] = s
+	return true
+}
+
+// syslogYear completes the year of the timestamp without the year.
+// The timestamp is assumed to be not in the future (a day of margin),
+// so December logs read in January are last year.
+func syslogYear(t time.Time, now time.Time) time.Time {
+	y := time.Date(now.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), 0, t.Location())
+	if y.After(now.AddDate(0, 0, 1)) {
+		y = y.AddDate(-1, 0, 0)
+	}
+	return y
+}
@@ -0,0 +1,145 @@
+package trdsql
+
+import (
+	"io"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewSyslogReader(t *testing.T) {
+	file, err := singleFileOpen(filepath.Join(dataDir, "syslog.log"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	r, err := NewSyslogReader(file, NewReadOpts(InPreRead(2)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	names, err := r.Names()
+	if err != nil {
+		t.Fatal(err)
+	}
+	types, err := r.Types()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(names) != len(types) {
+		t.Fatalf("SyslogReader names %v and types %v do not match", names, types)
+	}
+	want := [][]any{
+		{
+			34, "auth", "crit", time.Date(2003, 10, 11, 22, 14, 15, 3000000, time.UTC), "mymachine.example.com",
+			"su", nil, "ID47", nil, "'su root' failed for lonvick on /dev/pts/8",
+		},
+		{
+			165, "local4", "notice", time.Date(2003, 8, 24, 12, 14, 15, 3000, time.UTC), "192.0.2.1",
+			"myproc", "8710", nil, `[exampleSDID@32473 iut="3" eventSource="Application"]`, "An application event",
+		},
+	}
+	got := r.PreReadRow()
+	if len(got) != len(want) {
+		t.Fatalf("SyslogReader.PreReadRow() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if gt, ok := got[i][3].(time.Time); !ok || !gt.Equal(want[i][3].(time.Time)) {
+			t.Errorf("SyslogReader.PreReadRow()[%d] timestamp = %v, want %v", i, got[i][3], want[i][3])
+		}
+		got[i][3], want[i][3] = nil, nil
+		if !reflect.DeepEqual(got[i], want[i]) {
+			t.Errorf("SyslogReader.PreReadRow()[%d] = %v, want %v", i, got[i], want[i])
+		}
+	}
+
+	// RFC 3164 and /var/log/messages style.
+	wantRows := [][]any{
+		{86, "authpriv", "info", nil, "mymachine", "sshd", "1234", nil, nil, "Accepted publickey for frank"},
+		{nil, nil, nil, nil, "myhost", "kernel", nil, nil, nil, "Linux version 6.1.0"},
+	}
+	for _, w := range wantRows {
+		row := make([]any, len(names))
+		row, err := r.ReadRow(row)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, ok := row[3].(time.Time); !ok {
+			t.Errorf("SyslogReader.ReadRow() timestamp = %v", row[3])
+		}
+		row[3] = nil
+		if !reflect.DeepEqual(row, w) {
+			t.Errorf("SyslogReader.ReadRow() = %v, want %v", row, w)
+		}
+	}
+	// The unmatched line is skipped.
+	row := make([]any, len(names))
+	if _, err := r.ReadRow(row); err != io.EOF {
+		t.Errorf("SyslogReader.ReadRow() error = %v, want %v", err, io.EOF)
+	}
+}
+
+func Test_parseSyslog(t *testing.T) {
+	now := time.Date(2022, 1, 8, 10, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name   string
+		line   string
+		want   []any
+		wantOK bool
+	}{
+		{
+			name:   "lastYear",
+			line:   "<13>Dec 31 23:59:59 host app: msg",
+			want:   []any{13, "user", "notice", time.Date(2021, 12, 31, 23, 59, 59, 0, time.UTC), "host", "app", nil, nil, nil, "msg"},
+			wantOK: true,
+		},
+		{
+			name:   "rfc3339",
+			line:   "2022-01-08T10:20:30.5+09:00 host app[1]: a: b",
+			want:   []any{nil, nil, nil, time.Date(2022, 1, 8, 10, 20, 30, 500000000, time.FixedZone("", 9*60*60)), "host", "app", "1", nil, nil, "a: b"},
+			wantOK: true,
+		},
+		{
+			name:   "noTag",
+			line:   "Jan  8 09:00:00 host -- MARK --",
+			want:   []any{nil, nil, nil, time.Date(2022, 1, 8, 9, 0, 0, 0, time.UTC), "host", nil, nil, nil, nil, "-- MARK --"},
+			wantOK: true,
+		},
+		{
+			name:   "5424NoMessage",
+			line:   `<14>1 - - - - - [a@1 x="]\""][b@2]`,
+			want:   []any{14, "user", "info", nil, nil, nil, nil, nil, `[a@1 x="]\""][b@2]`, nil},
+			wantOK: true,
+		},
+		{
+			name:   "invalidPRI",
+			line:   "<999>Jan  8 09:00:00 host app: msg",
+			wantOK: false,
+		},
+		{
+			name:   "invalidSD",
+			line:   `<14>1 - - - - - [a@1 x="y"`,
+			wantOK: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := parseSyslog(tt.line, now)
+			if ok != tt.wantOK {
+				t.Fatalf("parseSyslog() ok = %v, want %v", ok, tt.wantOK)
+			}
+			if !ok {
+				return
+			}
+			if wt, ok := tt.want[3].(time.Time); ok {
+				if gt, ok := got[3].(time.Time); !ok || !gt.Equal(wt) {
+					t.Errorf("parseSyslog() timestamp = %v, want %v", got[3], wt)
+				}
+				got[3], tt.want[3] = nil, nil
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseSyslog() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,238 @@
+package trdsql
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+
+	runewidth "github.com/mattn/go-runewidth"
+)
+
+// TWReader reads the Markdown table and the ASCII table written by TWWriter.
+//
+//	| id | name   |      +----+--------+
+//	|----|--------|      | id |  name  |
+//	|  1 | Orange |      +----+--------+
+//	                     |  1 | Orange |
+//	                     +----+--------+
+//
+// The first row is the header.
+// Border lines (+---+) and the alignment row (|---|:--:|) after the header
+// or the first row of the file are ignored.
+// The rows of the ASCII table are split at the "+" of the border line,
+// so "|" in the values is kept. In the Markdown table, "\|" is a literal pipe.
+// Long columns wrapped on output cannot be restored,
+// so write with -onowrap to read them back.
+type TWReader struct {
+	reader    *bufio.Reader
+	inNULL    string
+	names     []string
+	types     []string
+	preRead   [][]any
+	border    []int
+	rows      int
+	alignment int
+	limitRead bool
+	needNULL  bool
+}
+
+// NewTWReader returns a TWReader configured with input options.
+func NewTWReader(reader io.Reader, opts *ReadOpts) (*TWReader, error) {
+	r := &TWReader{}
+	r.reader = bufio.NewReader(reader)
+	// The alignment row is expected as the second row of the file
+	// until the header is read.
+	r.alignment = 2
+
+	if opts.InSkip > 0 {
+		skipRead(r, opts.InSkip)
+	}
+
+	r.limitRead = opts.InLimitRead
+	r.needNULL = opts.InNeedNULL
+	r.inNULL = opts.InNULL
+
+	header, err := r.nextCells()
+	if err != nil {
+		if !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		debug.Print(err.Error())
+		return r, nil
+	}
+	r.alignment = r.rows + 1
+	r.names = make([]string, len(header))
+	r.types = make([]string, len(header))
+	for i, col := range header {
+		r.names[i] = col
+		if col == "" {
+			r.names[i] = "c" + strconv.Itoa(i+1)
+		}
+		r.types[i] = DefaultDBType
+	}
+
+	for range opts.InPreRead {
+		row := make([]any, len(r.names))
+		row, err := r.read(row)
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				return r, err
+			}
+			debug.Print(err.Error())
+			return r, nil
+		}
+		r.preRead = append(r.preRead, row)
+	}
+	return r, nil
+}
+
+// Names returns column names.
+func (r *TWReader) Names() ([]string, error) {
+	return r.names, nil
+}
+
+// Types returns column types.
+// All table types return the DefaultDBType.
+func (r *TWReader) Types() ([]string, error) {
+	return r.types, nil
+}
+
+// PreReadRow is returns only columns that store preread rows.
+func (r *TWReader) PreReadRow() [][]any {
+	return r.preRead
+}
+
+// ReadRow is read the rest of the row.
+func (r *TWReader) ReadRow(row []any) ([]any, error) {
+	if r.limitRead {
+		return nil, io.EOF
+	}
+	return r.read(row)
+}
+
+func (r *TWReader) read(row []any) ([]any, error) {
+	cells, err := r.nextCells()
+	if err != nil {
+		return row, err
+	}
+	for i := range row {
+		row[i] = nil
+		if i < len(cells) {
+			row[i] = cells[i]
+		}
+		if r.needNULL {
+			row[i] = replaceNULL(r.inNULL, row[i])
+		}
+	}
+	return row, nil
+}
+
+// nextCells returns the cells of the next table row.
+// Lines that are not table rows are skipped.
+func (r *TWReader) nextCells() ([]string, error) {
+	for {
+		line, err := r.reader.ReadString('\n')
+		if line == "" && err != nil {
+			return nil, err
+		}
+		line = strings.TrimSpace(line)
+		if line != "" && line[0] == '+' {
+			r.border = borderPositions(line)
+		}
+		if line == "" || line[0] == '+' || !strings.Contains(line, "|") {
+			if err != nil {
+				return nil, err
+			}
+			continue
+		}
+		cells, ok := splitByBorder(line, r.border)
+		if !ok {
+			cells = splitTableRow(line)
+		}
+		r.rows++
+		// The alignment row is only the row after the header of the Markdown table.
+		if r.rows == r.alignment && r.border == nil && isAlignmentRow(cells) {
+			continue
+		}
+		return cells, nil
+	}
+}
+
+// borderPositions returns the positions of "+" in the border line.
+func borderPositions(line string) []int {
+	var positions []int
+	for i, c := range line {
+		if c == '+' {
+			positions = append(positions, i)
+		}
+	}
+	return positions
+}
+
+// splitByBorder splits the row of the ASCII table at the positions of the border.
+// The positions are display widths, so East Asian wide characters are 2.
+// It returns false if the row does not match the border.
+func splitByBorder(line string, border []int) ([]string, bool) {
+	if len(border) < 2 {
+		return nil, false
+	}
+	var cells []string
+	var cell strings.Builder
+	pos, n := 0, 0
+	for _, c := range line {
+		if n < len(border) && pos == border[n] {
+			if c != '|' {
+				return nil, false
+			}
+			if n > 0 {
+				cells = append(cells, strings.TrimSpace(cell.String()))
+				cell.Reset()
+			}
+			n++
+		} else {
+			cell.WriteRune(c)
+		}
+		pos += runewidth.RuneWidth(c)
+	}
+	if n != len(border) || strings.TrimSpace(cell.String()) != "" {
+		return nil, false
+	}
+	return cells, true
+}
+
+// splitTableRow splits the table row into cells.
+// The leading and trailing pipes are optional, and "\|" is a literal pipe.
+func splitTableRow(line string) []string {
+	line = strings.TrimPrefix(line, "|")
+	if strings.HasSuffix(line, "|") && !strings.HasSuffix(line, `\|`) {
+		line = line[:len(line)-1]
+	}
+	var cells []string
+	var cell strings.Builder
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line) && line[i+1] == '|':
+			cell.WriteByte('|')
+			i++
+		case line[i] == '|':
+			cells = append(cells, strings.TrimSpace(cell.String()))
+			cell.Reset()
+		default:
+			cell.WriteByte(line[i])
+		}
+	}
+	return append(cells, strings.TrimSpace(cell.String()))
+}
+
+// isAlignmentRow returns true if all cells are like "---", ":--" or ":-:".
+func isAlignmentRow(cells []string) bool {
+	for _, cell := range cells {
+		c := strings.Trim(cell, ":")
+		if c == "" || strings.Trim(c, "-") != "" {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,161 @@
+package trdsql
+
+import (
+	"io"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewTWReader(t *testing.T) {
+	tests := []struct {
+		name        string
+		fileName    string
+		opts        *ReadOpts
+		wantNames   []string
+		wantPreRead [][]any
+	}{
+		{
+			name:        "md",
+			fileName:    "md.golden",
+			opts:        NewReadOpts(),
+			wantNames:   []string{"c1", "c2"},
+			wantPreRead: [][]any{{"1", "Orange"}},
+		},
+		{
+			name:        "at",
+			fileName:    "at.golden",
+			opts:        NewReadOpts(InPreRead(3)),
+			wantNames:   []string{"c1", "c2"},
+			wantPreRead: [][]any{{"1", "Orange"}, {"2", "Melon"}, {"3", "Apple"}},
+		},
+		{
+			name:        "alignment",
+			fileName:    "test.md",
+			opts:        NewReadOpts(InPreRead(3)),
+			wantNames:   []string{"id", "name", "price"},
+			wantPreRead: [][]any{{"1", "Orange", "50"}, {"2", "Melon", "500"}, {"3", "Apple", ""}},
+		},
+		{
+			name:        "skip",
+			fileName:    "test.md",
+			opts:        NewReadOpts(InSkip(1)),
+			wantNames:   []string{"1", "Orange", "50"},
+			wantPreRead: [][]any{{"2", "Melon", "500"}},
+		},
+		{
+			name:        "inNULL",
+			fileName:    "test.md",
+			opts:        NewReadOpts(InPreRead(3), InNeedNULL(true), InNULL("")),
+			wantNames:   []string{"id", "name", "price"},
+			wantPreRead: [][]any{{"1", "Orange", "50"}, {"2", "Melon", "500"}, {"3", "Apple", nil}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := singleFileOpen(filepath.Join(dataDir, tt.fileName))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer file.Close()
+			got, err := NewTWReader(file, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got.names, tt.wantNames) {
+				t.Errorf("NewTWReader().names = %v, want %v", got.names, tt.wantNames)
+			}
+			if !reflect.DeepEqual(got.PreReadRow(), tt.wantPreRead) {
+				t.Errorf("NewTWReader().PreReadRow() = %v, want %v", got.PreReadRow(), tt.wantPreRead)
+			}
+		})
+	}
+}
+
+func TestTWReader_ReadRow(t *testing.T) {
+	const table = `| a | b\|c |
+| x |
+| y | z | ignored |
+`
+	r, err := NewTWReader(strings.NewReader(table), NewReadOpts(InPreRead(0)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"a", "b|c"}; !reflect.DeepEqual(r.names, want) {
+		t.Errorf("TWReader.names = %v, want %v", r.names, want)
+	}
+	want := [][]any{{"x", nil}, {"y", "z"}}
+	for _, w := range want {
+		row := make([]any, len(r.names))
+		got, err := r.ReadRow(row)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, w) {
+			t.Errorf("TWReader.ReadRow() = %v, want %v", got, w)
+		}
+	}
+	row := make([]any, len(r.names))
+	if _, err := r.ReadRow(row); err != io.EOF {
+		t.Errorf("TWReader.ReadRow() error = %v, want %v", err, io.EOF)
+	}
+}
+
+func TestTWReaderRows(t *testing.T) {
+	tests := []struct {
+		name      string
+		table     string
+		skip      int
+		wantNames []string
+		want      [][]any
+	}{
+		{
+			name:      "dash row",
+			table:     "| a | b |\n|---|:-:|\n| - | - |\n| 1 | 2 |\n",
+			wantNames: []string{"a", "b"},
+			want:      [][]any{{"-", "-"}, {"1", "2"}},
+		},
+		{
+			name:      "skip prose",
+			table:     "price | fruit\n| a | b |\n|---|---|\n| 1 | 2 |\n",
+			skip:      1,
+			wantNames: []string{"a", "b"},
+			want:      [][]any{{"1", "2"}},
+		},
+		{
+			name:      "at pipe",
+			table:     "+-----+---+\n|  x  | y |\n+-----+---+\n| a|b | 1 |\n| --- | - |\n+-----+---+\n",
+			wantNames: []string{"x", "y"},
+			want:      [][]any{{"a|b", "1"}, {"---", "-"}},
+		},
+		{
+			name:      "at wide",
+			table:     "+-------+---+\n|   x   | y |\n+-------+---+\n| あ|い | 1 |\n+-------+---+\n",
+			wantNames: []string{"x", "y"},
+			want:      [][]any{{"あ|い", "1"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewTWReader(strings.NewReader(tt.table), NewReadOpts(InPreRead(0), InSkip(tt.skip)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(r.names, tt.wantNames) {
+				t.Errorf("TWReader.names = %v, want %v", r.names, tt.wantNames)
+			}
+			var got [][]any
+			for {
+				row, err := r.ReadRow(make([]any, len(r.names)))
+				if err != nil {
+					break
+				}
+				got = append(got, row)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("TWReader.ReadRow() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,118 @@
+package trdsql
+
+import (
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/BurntSushi/toml"
+)
+
+// The location names of the local date/time types of TOML.
+const (
+	tomlLocalDatetime = "datetime-local"
+	tomlLocalDate     = "date-local"
+	tomlLocalTime     = "time-local"
+)
+
+// NewTOMLReader returns a ConfigReader of the TOML file.
+func NewTOMLReader(reader io.Reader, opts *ReadOpts) (*ConfigReader, error) {
+	root, err := parseTOML(reader)
+	if err != nil {
+		return nil, err
+	}
+	return newConfigReader(root, opts)
+}
+
+// parseTOML parses the TOML file into configTable.
+func parseTOML(reader io.Reader) (*configTable, error) {
+	var doc map[string]any
+	md, err := toml.NewDecoder(reader).Decode(&doc)
+	if err != nil {
+		return nil, fmt.Errorf("toml: %w", err)
+	}
+	// The order of the keys in the file.
+	order := make(map[string][]string)
+	seen := make(map[string]bool)
+	for _, key := range md.Keys() {
+		for i := range key {
+			path := strings.Join(key[:i+1], ".")
+			if seen[path] {
+				continue
+			}
+			seen[path] = true
+			parent := strings.Join(key[:i], ".")
+			order[parent] = append(order[parent], key[i])
+		}
+	}
+	return tomlTable(doc, "", order), nil
+}
+
+// tomlTable converts the decoded table into configTable in the order of the file.
+func tomlTable(m map[string]any, path string, order map[string][]string) *configTable {
+	t := newConfigTable()
+	keys := make([]string, 0, len(m))
+	for _, key := range order[path] {
+		if _, ok := m[key]; ok {
+			keys = append(keys, key)
+		}
+	}
+	if len(keys) < len(m) {
+		var rest []string
+		for key := range m {
+			if !slices.Contains(keys, key) {
+				rest = append(rest, key)
+			}
+		}
+		sort.Strings(rest)
+		keys = append(keys, rest...)
+	}
+	for _, key := range keys {
+		sub := key
+		if path != "" {
+			sub = path + "." + key
+		}
+		t.set(key, tomlValue(m[key], sub, order))
+	}
+	return t
+}
+
+// tomlValue converts the decoded value.
+func tomlValue(v any, path string, order map[string][]string) any {
+	switch v := v.(type) {
+	case map[string]any:
+		return tomlTable(v, path, order)
+	case []map[string]any:
+		tables := make([]*configTable, len(v))
+		for i, m := range v {
+			tables[i] = tomlTable(m, path, order)
+		}
+		return tables
+	case []any:
+		list := make([]any, len(v))
+		for i, e := range v {
+			list[i] = tomlValue(e, path, order)
+		}
+		return list
+	default:
+		return v
+	}
+}
+
+// configTime returns the string of the time.
+// The local date/time types of TOML are formatted without the time zone.
+func configTime(t time.Time) string {
+	switch t.Location().String() {
+	case tomlLocalDatetime:
+		return t.Format("2006-01-02T15:04:05.999999999")
+	case tomlLocalDate:
+		return t.Format(time.DateOnly)
+	case tomlLocalTime:
+		return t.Format("15:04:05.999999999")
+	default:
+		return t.Format(time.RFC3339Nano)
+	}
+}
@@ -0,0 +1,202 @@
+package trdsql
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strings"
+
+	runewidth "github.com/mattn/go-runewidth"
+)
+
+// VFReader reads the vertical format written by VFWriter.
+// Each record block becomes a row, and each "name | value" line becomes a column.
+//
+//	---[ 1]------------------------
+//	  id | 1
+//	name | Orange
+//
+// The expanded display of psql (-[ RECORD 1 ]---) can also be read.
+// Lines that do not have the column name are the continuation of the previous value.
+type VFReader struct {
+	reader    *bufio.Reader
+	started   bool
+	already   map[string]bool
+	inNULL    string
+	preRead   []map[string]any
+	names     []string
+	types     []string
+	limitRead bool
+	needNULL  bool
+}
+
+// NewVFReader returns a VFReader configured with input options.
+func NewVFReader(reader io.Reader, opts *ReadOpts) (*VFReader, error) {
+	r := &VFReader{}
+	r.reader = bufio.NewReader(reader)
+	r.already = make(map[string]bool)
+
+	if opts.InSkip > 0 {
+		skipRead(r, opts.InSkip)
+	}
+
+	r.limitRead = opts.InLimitRead
+	r.needNULL = opts.InNeedNULL
+	r.inNULL = opts.InNULL
+
+	for range opts.InPreRead {
+		row, names, err := r.nextRow()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				return r, err
+			}
+			debug.Print(err.Error())
+			return r, nil
+		}
+		r.appendNames(names)
+		r.preRead = append(r.preRead, row)
+	}
+	return r, nil
+}
+
+// Names returns column names.
+func (r *VFReader) Names() ([]string, error) {
+	return r.names, nil
+}
+
+// Types returns column types.
+// All VF types return the DefaultDBType.
+func (r *VFReader) Types() ([]string, error) {
+	r.types = make([]string, len(r.names))
+	for i := 0; i < len(r.names); i++ {
+		r.types[i] = DefaultDBType
+	}
+	return r.types, nil
+}
+
+// appendNames adds multiple names for the argument to be unique.
+func (r *VFReader) appendNames(names []string) {
+	for _, name := range names {
+		if !r.already[name] {
+			r.already[name] = true
+			r.names = append(r.names, name)
+		}
+	}
+}
+
+// PreReadRow is returns only columns that store preRead rows.
+func (r *VFReader) PreReadRow() [][]any {
+	rows := make([][]any, len(r.preRead))
+	for n, v := range r.preRead {
+		rows[n] = make([]any, len(r.names))
+		for i := range r.names {
+			rows[n][i] = v[r.names[i]]
+			if r.needNULL {
+				rows[n][i] = replaceNULL(r.inNULL, rows[n][i])
+			}
+		}
+	}
+	return rows
+}
+
+// ReadRow is read the rest of the row.
+// Columns not found in the preread rows are ignored.
+func (r *VFReader) ReadRow(row []any) ([]any, error) {
+	if r.limitRead {
+		return nil, io.EOF
+	}
+	m, _, err := r.nextRow()
+	if err != nil {
+		return row, err
+	}
+	for i := 0; i < len(row) && i < len(r.names); i++ {
+		row[i] = m[r.names[i]]
+		if r.needNULL {
+			row[i] = replaceNULL(r.inNULL, row[i])
+		}
+	}
+	return row, nil
+}
+
+// nextRow reads the next record block and returns it as a row.
+func (r *VFReader) nextRow() (map[string]any, []string, error) {
+	var row map[string]any
+	var names []string
+	last := ""
+	sep := -1
+	for {
+		line, err := r.reader.ReadString('\n')
+		if line == "" && err != nil {
+			if row != nil && errors.Is(err, io.EOF) {
+				return row, names, nil
+			}
+			return nil, nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if isVFSeparator(line) {
+			if row != nil {
+				return row, names, nil
+			}
+			r.started = true
+			row = make(map[string]any)
+			continue
+		}
+		if !r.started {
+			continue
+		}
+		if row == nil {
+			// The block of the separator read in the previous call.
+			row = make(map[string]any)
+		}
+		if name, value, width, ok := vfField(line, sep); ok {
+			if _, ok := row[name]; !ok {
+				names = append(names, name)
+				row[name] = value
+				last = name
+				sep = width
+				continue
+			}
+		}
+		if last != "" {
+			row[last] = row[last].(string) + "\n" + line
+		}
+	}
+}
+
+// vfField splits the line into the column name and the value.
+// The names are aligned, so " | " must be at the same display width (sep)
+// as the previous column in the block,
+// otherwise the line is a continuation of the previous value.
+func vfField(line string, sep int) (string, string, int, bool) {
+	idx := strings.Index(line, " |")
+	if idx < 0 {
+		return "", "", 0, false
+	}
+	width := runewidth.StringWidth(line[:idx])
+	if sep >= 0 && width != sep {
+		return "", "", 0, false
+	}
+	value := line[idx+2:]
+	if value != "" && value[0] != ' ' {
+		return "", "", 0, false
+	}
+	name := strings.TrimSpace(line[:idx])
+	if name == "" {
+		return "", "", 0, false
+	}
+	return name, strings.TrimPrefix(value, " "), width, true
+}
+
+// isVFSeparator returns true if the line is the separator of the record
+// (---[ 1]--- or -[ RECORD 1 ]---).
+func isVFSeparator(line string) bool {
+	if !strings.HasPrefix(line, "-") {
+		return false
+	}
+	s := strings.TrimLeft(line, "-")
+	if !strings.HasPrefix(s, "[") {
+		return false
+	}
+	end := strings.Index(s, "]")
+	return end > 0 && strings.Trim(s[end+1:], "-+") == ""
+}
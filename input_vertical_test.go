@@ -0,0 +1,98 @@
+package trdsql
+
+import (
+	"io"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewVFReader(t *testing.T) {
+	tests := []struct {
+		name        string
+		fileName    string
+		opts        *ReadOpts
+		wantNames   []string
+		wantPreRead [][]any
+	}{
+		{
+			name:        "vf.golden",
+			fileName:    "vf.golden",
+			opts:        NewReadOpts(InPreRead(3)),
+			wantNames:   []string{"c1", "c2"},
+			wantPreRead: [][]any{{"1", "Orange"}, {"2", "Melon"}, {"3", "Apple"}},
+		},
+		{
+			name:        "continuation",
+			fileName:    "test.vf",
+			opts:        NewReadOpts(InPreRead(3)),
+			wantNames:   []string{"id", "name", "price"},
+			wantPreRead: [][]any{{"1", "Orange", "50"}, {"2", "Melon", "500"}, {"3", "Apple\npie", ""}},
+		},
+		{
+			name:        "skip",
+			fileName:    "test.vf",
+			opts:        NewReadOpts(InSkip(2)),
+			wantNames:   []string{"id", "name", "price"},
+			wantPreRead: [][]any{{"3", "Apple\npie", ""}},
+		},
+		{
+			name:        "inNULL",
+			fileName:    "test.vf",
+			opts:        NewReadOpts(InPreRead(3), InNeedNULL(true), InNULL("")),
+			wantNames:   []string{"id", "name", "price"},
+			wantPreRead: [][]any{{"1", "Orange", "50"}, {"2", "Melon", "500"}, {"3", "Apple\npie", nil}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := singleFileOpen(filepath.Join(dataDir, tt.fileName))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer file.Close()
+			got, err := NewVFReader(file, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got.names, tt.wantNames) {
+				t.Errorf("NewVFReader().names = %v, want %v", got.names, tt.wantNames)
+			}
+			if !reflect.DeepEqual(got.PreReadRow(), tt.wantPreRead) {
+				t.Errorf("NewVFReader().PreReadRow() = %v, want %v", got.PreReadRow(), tt.wantPreRead)
+			}
+		})
+	}
+}
+
+func TestVFReaderPsql(t *testing.T) {
+	const expanded = `-[ RECORD 1 ]-----
+id   | 1
+name | a | b
+-[ RECORD 2 ]-----
+id   | 2
+name |
+`
+	r, err := NewVFReader(strings.NewReader(expanded), NewReadOpts(InPreRead(1)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"id", "name"}; !reflect.DeepEqual(r.names, want) {
+		t.Errorf("VFReader.names = %v, want %v", r.names, want)
+	}
+	if want := [][]any{{"1", "a | b"}}; !reflect.DeepEqual(r.PreReadRow(), want) {
+		t.Errorf("VFReader.PreReadRow() = %v, want %v", r.PreReadRow(), want)
+	}
+	row := make([]any, len(r.names))
+	got, err := r.ReadRow(row)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []any{"2", ""}; !reflect.DeepEqual(got, want) {
+		t.Errorf("VFReader.ReadRow() = %v, want %v", got, want)
+	}
+	if _, err := r.ReadRow(row); err != io.EOF {
+		t.Errorf("VFReader.ReadRow() error = %v, want %v", err, io.EOF)
+	}
+}
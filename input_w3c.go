@@ -0,0 +1,226 @@
+package trdsql
+
+import (
+	"bufio"
+	"errors"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// W3CReader reads the W3C Extended Log File Format (used by IIS and others).
+// The column names are taken from the #Fields: directive.
+//
+//	#Fields: date time c-ip cs-method cs-uri-stem sc-status
+//	2022-01-08 00:00:01 192.168.0.1 GET /index.html 200
+//
+// "-" is NULL. When the #Fields: directive changes in the middle of the file,
+// the fields are mapped to the columns of the first directive by name.
+type W3CReader struct {
+	reader    *bufio.Reader
+	fields    []int
+	inNULL    string
+	names     []string
+	types     []string
+	preRead   [][]any
+	pending   []string
+	limitRead bool
+	needNULL  bool
+}
+
+// NewW3CReader returns a W3CReader configured with input options.
+func NewW3CReader(reader io.Reader, opts *ReadOpts) (*W3CReader, error) {
+	r := &W3CReader{}
+	r.reader = bufio.NewReader(reader)
+
+	// Read up to the first data line to get the column names.
+	cells, err := r.nextCells()
+	if err != nil {
+		if !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		debug.Print(err.Error())
+		return r, nil
+	}
+	if r.names == nil {
+		// No #Fields: directive.
+		r.setFields(nil, len(cells))
+	}
+	r.pending = cells
+
+	if opts.InSkip > 0 {
+		skipRead(r, opts.InSkip)
+	}
+
+	r.limitRead = opts.InLimitRead
+	r.needNULL = opts.InNeedNULL
+	r.inNULL = opts.InNULL
+
+	for range opts.InPreRead {
+		row := make([]any, len(r.names))
+		row, err := r.read(row)
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				return r, err
+			}
+			debug.Print(err.Error())
+			return r, nil
+		}
+		r.preRead = append(r.preRead, row)
+	}
+	return r, nil
+}
+
+// setFields sets the fields of the #Fields: directive.
+// The first directive determines the columns,
+// and the later directives are mapped to the columns by name.
+func (r *W3CReader) setFields(fields []string, n int) {
+	if r.names == nil {
+		if fields == nil {
+			fields = make([]string, n)
+			for i := range fields {
+				fields[i] = "c" + strconv.Itoa(i+1)
+			}
+		}
+		r.names = fields
+		r.types = make([]string, len(fields))
+		for i, name := range fields {
+			r.types[i] = w3cDBType(name)
+		}
+	}
+	r.fields = make([]int, len(fields))
+	for i, field := range fields {
+		r.fields[i] = -1
+		for j, name := range r.names {
+			if name == field {
+				r.fields[i] = j
+				break
+			}
+		}
+	}
+}
+
+// w3cDBType returns the database type of the field.
+func w3cDBType(field string) string {
+	switch strings.ToLower(field) {
+	case "date":
+		return "date"
+	case "sc-status", "sc-substatus", "sc-win32-status", "s-port", "time-taken":
+		return "int"
+	case "sc-bytes", "cs-bytes", "bytes":
+		return "bigint"
+	default:
+		return DefaultDBType
+	}
+}
+
+// Names returns column names.
+func (r *W3CReader) Names() ([]string, error) {
+	return r.names, nil
+}
+
+// Types returns column types.
+func (r *W3CReader) Types() ([]string, error) {
+	return r.types, nil
+}
+
+// PreReadRow is returns only columns that store preread rows.
+func (r *W3CReader) PreReadRow() [][]any {
+	return r.preRead
+}
+
+// ReadRow is read the rest of the row.
+func (r *W3CReader) ReadRow(row []any) ([]any, error) {
+	if r.limitRead {
+		return nil, io.EOF
+	}
+	return r.read(row)
+}
+
+func (r *W3CReader) read(row []any) ([]any, error) {
+	cells := r.pending
+	r.pending = nil
+	if cells == nil {
+		var err error
+		cells, err = r.nextCells()
+		if err != nil {
+			return row, err
+		}
+	}
+	for i := range row {
+		row[i] = nil
+	}
+	for i, cell := range cells {
+		if i >= len(r.fields) || r.fields[i] < 0 || r.fields[i] >= len(row) {
+			continue
+		}
+		var v any
+		if cell != "-" {
+			v = cell
+		}
+		if r.needNULL {
+			v = replaceNULL(r.inNULL, v)
+		}
+		row[r.fields[i]] = v
+	}
+	return row, nil
+}
+
+// nextCells returns the fields of the next data line.
+// Directive lines are processed and skipped.
+func (r *W3CReader) nextCells() ([]string, error) {
+	for {
+		line, err := r.reader.ReadString('\n')
+		if line == "" && err != nil {
+			return nil, err
+		}
+		line = strings.TrimRight(line, "\r\n")
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		if strings.HasPrefix(line, "#") {
+			if fields, ok := strings.CutPrefix(line, "#Fields:"); ok {
+				r.setFields(strings.Fields(fields), 0)
+			}
+			continue
+		}
+		return splitW3C(line), nil
+	}
+}
+
+// splitW3C splits the line with spaces.
+// Fields enclosed in double quotes can contain spaces ("" is a double quote).
+func splitW3C(line string) []string {
+	var cells []string
+	var cell strings.Builder
+	quoted, inCell := false, false
+	for i := 0; i < len(line); i++ {
+		c := line[i]
+		switch {
+		case quoted && c == '"':
+			if i+1 < len(line) && line[i+1] == '"' {
+				cell.WriteByte('"')
+				i++
+				continue
+			}
+			quoted = false
+		case quoted:
+			cell.WriteByte(c)
+		case c == ' ' || c == '\t':
+			if inCell {
+				cells = append(cells, cell.String())
+				cell.Reset()
+				inCell = false
+			}
+		case c == '"' && !inCell:
+			quoted, inCell = true, true
+		default:
+			cell.WriteByte(c)
+			inCell = true
+		}
+	}
+	if inCell {
+		cells = append(cells, cell.String())
+	}
+	return cells
+}
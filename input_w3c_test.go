@@ -0,0 +1,94 @@
+package trdsql
+
+import (
+	"io"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewW3CReader(t *testing.T) {
+	tests := []struct {
+		name        string
+		opts        *ReadOpts
+		wantPreRead [][]any
+	}{
+		{
+			name: "fields",
+			opts: NewReadOpts(InPreRead(3)),
+			wantPreRead: [][]any{
+				{"2022-01-08", "00:00:01", "10.0.0.1", "GET", "/index.html", nil, "80", nil, "192.168.0.1", "Mozilla/5.0+(Windows+NT+10.0)", nil, "200", "0", "0", "15"},
+				{"2022-01-08", "00:00:02", "10.0.0.1", "GET", "/search", "q=apple", "80", "frank", "192.168.0.2", "curl/7.68.0", "http://example.com/", "404", "0", "2", "3"},
+				// The fields of the second directive are mapped by name.
+				{"2022-01-09", "00:00:03", nil, "POST", "/login", nil, nil, nil, "192.168.0.3", nil, nil, "302", nil, nil, nil},
+			},
+		},
+		{
+			name: "skip",
+			opts: NewReadOpts(InSkip(1)),
+			wantPreRead: [][]any{
+				{"2022-01-08", "00:00:02", "10.0.0.1", "GET", "/search", "q=apple", "80", "frank", "192.168.0.2", "curl/7.68.0", "http://example.com/", "404", "0", "2", "3"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := singleFileOpen(filepath.Join(dataDir, "w3c.log"))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer file.Close()
+			r, err := NewW3CReader(file, tt.opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			wantNames := []string{
+				"date", "time", "s-ip", "cs-method", "cs-uri-stem", "cs-uri-query", "s-port", "cs-username",
+				"c-ip", "cs(User-Agent)", "cs(Referer)", "sc-status", "sc-substatus", "sc-win32-status", "time-taken",
+			}
+			if !reflect.DeepEqual(r.names, wantNames) {
+				t.Errorf("NewW3CReader().names = %v, want %v", r.names, wantNames)
+			}
+			wantTypes := []string{
+				"date", "text", "text", "text", "text", "text", "int", "text",
+				"text", "text", "text", "int", "int", "int", "int",
+			}
+			if !reflect.DeepEqual(r.types, wantTypes) {
+				t.Errorf("NewW3CReader().types = %v, want %v", r.types, wantTypes)
+			}
+			if got := r.PreReadRow(); !reflect.DeepEqual(got, tt.wantPreRead) {
+				t.Errorf("NewW3CReader().PreReadRow() = %v, want %v", got, tt.wantPreRead)
+			}
+		})
+	}
+}
+
+func TestW3CReaderNoFields(t *testing.T) {
+	const w3c = `#Version: 1.0
+2022-01-08 "a b" """quoted"""
+2022-01-09 "" -
+`
+	r, err := NewW3CReader(strings.NewReader(w3c), NewReadOpts(InPreRead(0)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"c1", "c2", "c3"}; !reflect.DeepEqual(r.names, want) {
+		t.Errorf("W3CReader.names = %v, want %v", r.names, want)
+	}
+	want := [][]any{{"2022-01-08", "a b", `"quoted"`}, {"2022-01-09", "", nil}}
+	for _, w := range want {
+		row := make([]any, len(r.names))
+		got, err := r.ReadRow(row)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, w) {
+			t.Errorf("W3CReader.ReadRow() = %v, want %v", got, w)
+		}
+	}
+	row := make([]any, len(r.names))
+	if _, err := r.ReadRow(row); err != io.EOF {
+		t.Errorf("W3CReader.ReadRow() error = %v, want %v", err, io.EOF)
+	}
+}
@@ -0,0 +1,387 @@
+package trdsql
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/xuri/excelize/v2"
+)
+
+// ErrNoSheet is returned when the specified sheet does not exist.
+var ErrNoSheet = errors.New("no such sheet")
+
+// XLSXReader reads a sheet of an Excel XLSX file.
+// The sheet is selected by name or 1-based index with InSelector
+// (file.xlsx::Sheet2), and the first sheet is used if it is not specified.
+type XLSXReader struct {
+	file      *excelize.File
+	rows      *excelize.Rows
+	sheet     string
+	inNULL    string
+	names     []string
+	types     []string
+	preRead   [][]any
+	rowNum    int
+	date1904  bool
+	limitRead bool
+	needNULL  bool
+}
+
+// NewXLSXReader returns a XLSXReader configured with input options.
+func NewXLSXReader(reader io.Reader, opts *ReadOpts) (*XLSXReader, error) {
+	r := &XLSXReader{}
+	f, err := excelize.OpenReader(reader)
+	if err != nil {
+		return nil, fmt.Errorf("xlsx: %w", err)
+	}
+	r.file = f
+
+	r.sheet, err = xlsxSheet(f, opts.InSelector)
+	if err != nil {
+		return nil, err
+	}
+	debug.Printf("xlsx sheet: [%s]", r.sheet)
+	if props, err := f.GetWorkbookProps(); err == nil && props.Date1904 != nil {
+		r.date1904 = *props.Date1904
+	}
+
+	r.rows, err = f.Rows(r.sheet)
+	if err != nil {
+		return nil, fmt.Errorf("xlsx: %w", err)
+	}
+
+	if opts.InSkip > 0 {
+		skipRead(r, opts.InSkip)
+	}
+
+	r.limitRead = opts.InLimitRead
+	r.needNULL = opts.InNeedNULL
+	r.inNULL = opts.InNULL
+
+	// Read the header.
+	preReadN := opts.InPreRead
+	if opts.InHeader {
+		row, err := r.columns()
+		if err != nil && !errors.Is(err, io.EOF) {
+			return nil, err
+		}
+		r.names = make([]string, len(row))
+		for i, col := range row {
+			r.names[i] = col
+			if col == "" {
+				r.names[i] = "c" + strconv.Itoa(i+1)
+			}
+		}
+		preReadN--
+	}
+
+	// Pre-read and stored in slices.
+	var preRead [][]string
+	firstRow := r.rowNum + 1
+	for n := 0; n < preReadN; n++ {
+		row, err := r.columns()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				return r, err
+			}
+			debug.Print(err.Error())
+			break
+		}
+		for i := len(r.names); i < len(row); i++ {
+			// If there are more columns than header, add column names.
+			r.names = append(r.names, "c"+strconv.Itoa(i+1))
+		}
+		preRead = append(preRead, row)
+	}
+	r.setColumnType(firstRow, len(preRead))
+	for n, row := range preRead {
+		r.preRead = append(r.preRead, r.convert(row, make([]any, len(r.names)), firstRow+n))
+	}
+	return r, nil
+}
+
+// xlsxSheet returns the sheet name specified by the selector.
+// The selector is a sheet name or a 1-based index.
+func xlsxSheet(f *excelize.File, selector string) (string, error) {
+	list := f.GetSheetList()
+	if len(list) == 0 {
+		return "", fmt.Errorf("xlsx: %w", ErrNoSheet)
+	}
+	if selector == "" {
+		return list[0], nil
+	}
+	for _, name := range list {
+		if name == selector {
+			return name, nil
+		}
+	}
+	if n, err := strconv.Atoi(selector); err == nil && n > 0 && n <= len(list) {
+		return list[n-1], nil
+	}
+	return "", fmt.Errorf("xlsx: %w: %s", ErrNoSheet, selector)
+}
+
+// columns returns the raw values of the next row.
+func (r *XLSXReader) columns() ([]string, error) {
+	if r.rows == nil || !r.rows.Next() {
+		if r.rows != nil {
+			if err := r.rows.Error(); err != nil {
+				return nil, err
+			}
+		}
+		return nil, io.EOF
+	}
+	r.rowNum++
+	return r.rows.Columns(excelize.Options{RawCellValue: true})
+}
+
+// setColumnType sets the column types from the cells of the n pre-read
+// data rows starting at firstRow. Empty cells are ignored.
+// Boolean cells are bool, date formatted cells are timestamp,
+// numeric cells are numeric, and the others are DefaultDBType.
+// A column whose cells have different types is DefaultDBType.
+func (r *XLSXReader) setColumnType(firstRow int, n int) {
+	r.types = make([]string, len(r.names))
+	for i := range r.names {
+		typ := ""
+		for rowNum := firstRow; rowNum < firstRow+n; rowNum++ {
+			t := r.cellType(i+1, rowNum)
+			if t == "" {
+				continue
+			}
+			if typ != "" && typ != t {
+				typ = DefaultDBType
+				break
+			}
+			typ = t
+		}
+		if typ == "" {
+			typ = DefaultDBType
+		}
+		r.types[i] = typ
+	}
+}
+
+// cellType returns the type of the cell, or an empty string if the cell is empty.
+func (r *XLSXReader) cellType(col int, rowNum int) string {
+	if r.file == nil {
+		return DefaultDBType
+	}
+	cell, err := excelize.CoordinatesToCellName(col, rowNum)
+	if err != nil {
+		return DefaultDBType
+	}
+	v, err := r.file.GetCellValue(r.sheet, cell, excelize.Options{RawCellValue: true})
+	if err != nil {
+		return DefaultDBType
+	}
+	if v == "" {
+		return ""
+	}
+	t, err := r.file.GetCellType(r.sheet, cell)
+	if err != nil {
+		return DefaultDBType
+	}
+	switch t {
+	case excelize.CellTypeBool:
+		return "bool"
+	case excelize.CellTypeDate:
+		return "timestamp"
+	case excelize.CellTypeUnset, excelize.CellTypeNumber:
+		if _, err := strconv.ParseFloat(v, 64); err != nil {
+			return DefaultDBType
+		}
+		if r.isDateCell(cell) {
+			return "timestamp"
+		}
+		return "numeric"
+	default:
+		return DefaultDBType
+	}
+}
+
+// isDateCell returns true if the number format of the cell is a date or time.
+func (r *XLSXReader) isDateCell(cell string) bool {
+	idx, err := r.file.GetCellStyle(r.sheet, cell)
+	if err != nil || idx == 0 {
+		return false
+	}
+	style, err := r.file.GetStyle(idx)
+	if err != nil {
+		return false
+	}
+	if style.CustomNumFmt != nil {
+		return isDateFormatCode(*style.CustomNumFmt)
+	}
+	return isDateNumFmt(style.NumFmt)
+}
+
+// isDateNumFmt returns true if the built-in number format is a date or time.
+func isDateNumFmt(id int) bool {
+	switch {
+	case id >= 14 && id <= 22,
+		id >= 27 && id <= 36,
+		id >= 45 && id <= 47,
+		id >= 50 && id <= 58:
+		return true
+	}
+	return false
+}
+
+// isDateFormatCode returns true if the custom number format code contains
+// date or time placeholders outside of quoted strings and brackets.
+func isDateFormatCode(code string) bool {
+	inQuote, inBracket, escaped := false, false, false
+	for _, c := range strings.ToLower(code) {
+		switch {
+		case escaped:
+			escaped = false
+		case c == '\\':
+			escaped = true
+		case c == '"':
+			inQuote = !inQuote
+		case inQuote:
+		case c == '[':
+			inBracket = true
+		case c == ']':
+			inBracket = false
+		case inBracket:
+		case strings.ContainsRune("ymdhs", c):
+			return true
+		}
+	}
+	return false
+}
+
+// convert converts the raw values of the row rowNum
+// to the values according to the column types.
+func (r *XLSXReader) convert(columns []string, row []any, rowNum int) []any {
+	for i := range row {
+		if i >= len(columns) {
+			row[i] = nil
+			continue
+		}
+		dbType := DefaultDBType
+		if i < len(r.types) {
+			dbType = r.types[i]
+		}
+		row[i] = r.value(columns[i], dbType)
+		if dbType == DefaultDBType {
+			row[i] = r.dateString(columns[i], i+1, rowNum)
+		}
+		if r.needNULL {
+			row[i] = replaceNULL(r.inNULL, row[i])
+		}
+	}
+	return row
+}
+
+func (r *XLSXReader) value(v string, dbType string) any {
+	if dbType == DefaultDBType {
+		return v
+	}
+	if v == "" {
+		return nil
+	}
+	switch dbType {
+	case "bool":
+		switch v {
+		case "1", "TRUE", "true":
+			return true
+		case "0", "FALSE", "false":
+			return false
+		}
+	case "timestamp":
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			if t, err := time.Parse(time.RFC3339Nano, v); err == nil {
+				return t
+			}
+			return v
+		}
+		t, err := excelize.ExcelDateToTime(f, r.date1904)
+		if err != nil {
+			return v
+		}
+		return t
+	}
+	return v
+}
+
+// dateString returns the date formatted cell of a text column as a date string
+// instead of the serial number, and the other values as they are.
+func (r *XLSXReader) dateString(v string, col int, rowNum int) string {
+	if r.file == nil || v == "" {
+		return v
+	}
+	f, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return v
+	}
+	cell, err := excelize.CoordinatesToCellName(col, rowNum)
+	if err != nil || !r.isDateCell(cell) {
+		return v
+	}
+	t, err := excelize.ExcelDateToTime(f, r.date1904)
+	if err != nil {
+		return v
+	}
+	if t.Hour() == 0 && t.Minute() == 0 && t.Second() == 0 && t.Nanosecond() == 0 {
+		return t.Format(time.DateOnly)
+	}
+	return t.Format(time.DateTime)
+}
+
+// Names returns column names.
+func (r *XLSXReader) Names() ([]string, error) {
+	if len(r.names) == 0 {
+		return r.names, ErrNoRows
+	}
+	return r.names, nil
+}
+
+// Types returns column types.
+// The types are guessed from the cells of the pre-read data rows.
+func (r *XLSXReader) Types() ([]string, error) {
+	if len(r.types) == 0 {
+		return r.types, ErrNoRows
+	}
+	return r.types, nil
+}
+
+// PreReadRow is returns only columns that store preread rows.
+func (r *XLSXReader) PreReadRow() [][]any {
+	return r.preRead
+}
+
+// ReadRow is read the rest of the row.
+func (r *XLSXReader) ReadRow(row []any) ([]any, error) {
+	if r.limitRead {
+		return nil, io.EOF
+	}
+	columns, err := r.columns()
+	if err != nil {
+		r.close()
+		return row, err
+	}
+	return r.convert(columns, row, r.rowNum), nil
+}
+
+func (r *XLSXReader) close() {
+	if r.rows != nil {
+		if err := r.rows.Close(); err != nil {
+			debug.Printf("xlsx: %s", err)
+		}
+		r.rows = nil
+	}
+	if r.file != nil {
+		if err := r.file.Close(); err != nil {
+			debug.Printf("xlsx: %s", err)
+		}
+		r.file = nil
+	}
+}
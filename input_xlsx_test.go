@@ -0,0 +1,177 @@
+package trdsql
+
+import (
+	"io"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestNewXLSXReader(t *testing.T) {
+	day := func(d int) time.Time {
+		return time.Date(2022, 1, d, 0, 0, 0, 0, time.UTC)
+	}
+	tests := []struct {
+		name        string
+		fileName    string
+		opts        *ReadOpts
+		wantNames   []string
+		wantTypes   []string
+		wantPreRead [][]any
+		wantErr     bool
+	}{
+		{
+			name:        "noHeader",
+			fileName:    "test.xlsx",
+			opts:        NewReadOpts(),
+			wantNames:   []string{"c1", "c2", "c3", "c4", "c5"},
+			wantTypes:   []string{"text", "text", "text", "text", "text"},
+			wantPreRead: [][]any{{"id", "name", "price", "day", "fresh"}},
+			wantErr:     false,
+		},
+		{
+			name:      "noHeaderPreRead",
+			fileName:  "test.xlsx",
+			opts:      NewReadOpts(InPreRead(2)),
+			wantNames: []string{"c1", "c2", "c3", "c4", "c5"},
+			wantTypes: []string{"text", "text", "text", "text", "text"},
+			wantPreRead: [][]any{
+				{"id", "name", "price", "day", "fresh"},
+				{"1", "Orange", "50.5", "2022-01-08", "1"},
+			},
+			wantErr: false,
+		},
+		{
+			name:        "header",
+			fileName:    "test.xlsx",
+			opts:        NewReadOpts(InHeader(true), InPreRead(2)),
+			wantNames:   []string{"id", "name", "price", "day", "fresh"},
+			wantTypes:   []string{"numeric", "text", "numeric", "timestamp", "bool"},
+			wantPreRead: [][]any{{"1", "Orange", "50.5", day(8), true}},
+			wantErr:     false,
+		},
+		{
+			name:      "skip",
+			fileName:  "test.xlsx",
+			opts:      NewReadOpts(InSkip(3), InPreRead(2)),
+			wantNames: []string{"c1", "c2", "c3", "c4", "c5"},
+			wantTypes: []string{"numeric", "text", "text", "timestamp", "bool"},
+			wantPreRead: [][]any{
+				{"3", "Apple", "", day(10), true},
+			},
+			wantErr: false,
+		},
+		{
+			name:        "sheetName",
+			fileName:    "test.xlsx",
+			opts:        NewReadOpts(InHeader(true), InSelector("note")),
+			wantNames:   []string{"a", "b"},
+			wantTypes:   []string{"text", "text"},
+			wantPreRead: [][]any{},
+			wantErr:     false,
+		},
+		{
+			name:        "sheetIndex",
+			fileName:    "test.xlsx",
+			opts:        NewReadOpts(InSelector("2")),
+			wantNames:   []string{"c1", "c2"},
+			wantTypes:   []string{"text", "text"},
+			wantPreRead: [][]any{{"a", "b"}},
+			wantErr:     false,
+		},
+		{
+			name:     "noSheet",
+			fileName: "test.xlsx",
+			opts:     NewReadOpts(InSelector("3")),
+			wantErr:  true,
+		},
+		{
+			name:     "notXLSX",
+			fileName: "test.csv",
+			opts:     NewReadOpts(),
+			wantErr:  true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := singleFileOpen(filepath.Join(dataDir, tt.fileName))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer file.Close()
+			got, err := NewXLSXReader(file, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewXLSXReader() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got.names, tt.wantNames) {
+				t.Errorf("NewXLSXReader().names = %v, want %v", got.names, tt.wantNames)
+			}
+			if !reflect.DeepEqual(got.types, tt.wantTypes) {
+				t.Errorf("NewXLSXReader().types = %v, want %v", got.types, tt.wantTypes)
+			}
+			if len(got.PreReadRow()) != len(tt.wantPreRead) {
+				t.Fatalf("NewXLSXReader().PreReadRow() = %v, want %v", got.PreReadRow(), tt.wantPreRead)
+			}
+			for i, row := range got.PreReadRow() {
+				if !reflect.DeepEqual(row, tt.wantPreRead[i]) {
+					t.Errorf("NewXLSXReader().PreReadRow() = %v, want %v", row, tt.wantPreRead[i])
+				}
+			}
+		})
+	}
+}
+
+func TestXLSXReader_ReadRow(t *testing.T) {
+	file, err := singleFileOpen(filepath.Join(dataDir, "test.xlsx"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer file.Close()
+	r, err := NewXLSXReader(file, NewReadOpts(InHeader(true), InPreRead(2)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]any{
+		{"2", "Melon", "500", time.Date(2022, 1, 9, 0, 0, 0, 0, time.UTC), false},
+		{"3", "Apple", nil, time.Date(2022, 1, 10, 0, 0, 0, 0, time.UTC), true},
+	}
+	for _, w := range want {
+		row := make([]any, len(r.names))
+		got, err := r.ReadRow(row)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !reflect.DeepEqual(got, w) {
+			t.Errorf("XLSXReader.ReadRow() = %v, want %v", got, w)
+		}
+	}
+	row := make([]any, len(r.names))
+	if _, err := r.ReadRow(row); err != io.EOF {
+		t.Errorf("XLSXReader.ReadRow() error = %v, want %v", err, io.EOF)
+	}
+}
+
+func Test_isDateFormatCode(t *testing.T) {
+	tests := []struct {
+		code string
+		want bool
+	}{
+		{code: "yyyy/mm/dd", want: true},
+		{code: "h:mm:ss", want: true},
+		{code: "0.00", want: false},
+		{code: `#,##0 "days"`, want: false},
+		{code: "[Red]0.0", want: false},
+		{code: "[$-409]mmm d", want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.code, func(t *testing.T) {
+			if got := isDateFormatCode(tt.code); got != tt.want {
+				t.Errorf("isDateFormatCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,306 @@
+package trdsql
+
+// Convert XML to a table.
+// The elements that match the path become rows,
+// and their attributes and child elements become columns.
+//
+//	<catalog>
+//	  <item id="1"><name>Orange</name></item>
+//	  <item id="2"><name>Melon</name></item>
+//	</catalog>
+//
+// feed.xml::/catalog/item makes columns @id and name.
+import (
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+
+	"golang.org/x/net/html/charset"
+)
+
+// ErrInvalidXML is returned when the XML is invalid.
+var ErrInvalidXML = errors.New("invalid XML")
+
+// XMLReader parses XML elements as tabular input.
+type XMLReader struct {
+	decoder   *xml.Decoder
+	path      []string
+	absolute  bool
+	stack     []string
+	already   map[string]bool
+	inNULL    string
+	preRead   []map[string]any
+	names     []string
+	types     []string
+	limitRead bool
+	needNULL  bool
+}
+
+// NewXMLReader returns a XMLReader configured with input options.
+// The row elements are selected by the path of InSelector (file.xml::/catalog/item).
+// A path starting with "//" or without "/" matches at any depth,
+// and "*" matches any element name.
+// If the path is not specified, the child elements of the root element are rows.
+// The encoding declared in the XML declaration (e.g. ISO-8859-1, Shift_JIS) is converted to UTF-8.
+func NewXMLReader(reader io.Reader, opts *ReadOpts) (*XMLReader, error) {
+	r := &XMLReader{}
+	r.decoder = xml.NewDecoder(reader)
+	r.decoder.CharsetReader = charset.NewReaderLabel
+	r.already = make(map[string]bool)
+	r.path, r.absolute = xmlPath(opts.InSelector)
+
+	if opts.InSkip > 0 {
+		skipRead(r, opts.InSkip)
+	}
+
+	r.limitRead = opts.InLimitRead
+	r.needNULL = opts.InNeedNULL
+	r.inNULL = opts.InNULL
+
+	for range opts.InPreRead {
+		row, names, err := r.nextRow()
+		if err != nil {
+			if !errors.Is(err, io.EOF) {
+				return r, err
+			}
+			debug.Print(err.Error())
+			return r, nil
+		}
+		r.appendNames(names)
+		r.preRead = append(r.preRead, row)
+	}
+	return r, nil
+}
+
+// xmlPath splits the path into element names.
+func xmlPath(path string) ([]string, bool) {
+	path = trimQuoteAll(path)
+	if path == "" {
+		return []string{"*", "*"}, true
+	}
+	absolute := strings.HasPrefix(path, "/") && !strings.HasPrefix(path, "//")
+	path = strings.Trim(path, "/")
+	return strings.Split(path, "/"), absolute
+}
+
+// match returns true if the current element stack matches the path.
+func (r *XMLReader) match() bool {
+	if r.absolute && len(r.stack) != len(r.path) {
+		return false
+	}
+	if len(r.stack) < len(r.path) {
+		return false
+	}
+	top := r.stack[len(r.stack)-len(r.path):]
+	for i, name := range r.path {
+		if name != "*" && name != top[i] {
+			return false
+		}
+	}
+	return true
+}
+
+// Names returns column names.
+func (r *XMLReader) Names() ([]string, error) {
+	return r.names, nil
+}
+
+// Types returns column types.
+// All XML types return the DefaultDBType.
+func (r *XMLReader) Types() ([]string, error) {
+	r.types = make([]string, len(r.names))
+	for i := 0; i < len(r.names); i++ {
+		r.types[i] = DefaultDBType
+	}
+	return r.types, nil
+}
+
+// appendNames adds multiple names for the argument to be unique.
+func (r *XMLReader) appendNames(names []string) {
+	for _, name := range names {
+		if !r.already[name] {
+			r.already[name] = true
+			r.names = append(r.names, name)
+		}
+	}
+}
+
+// PreReadRow is returns only columns that store preRead rows.
+func (r *XMLReader) PreReadRow() [][]any {
+	rows := make([][]any, len(r.preRead))
+	for n, v := range r.preRead {
+		rows[n] = make([]any, len(r.names))
+		for i := range r.names {
+			rows[n][i] = v[r.names[i]]
+			if r.needNULL {
+				rows[n][i] = replaceNULL(r.inNULL, rows[n][i])
+			}
+		}
+	}
+	return rows
+}
+
+// ReadRow is read the rest of the row.
+// Columns not found in the preread rows are ignored.
+func (r *XMLReader) ReadRow(row []any) ([]any, error) {
+	if r.limitRead {
+		return nil, io.EOF
+	}
+	m, _, err := r.nextRow()
+	if err != nil {
+		return row, err
+	}
+	for i := 0; i < len(row) && i < len(r.names); i++ {
+		row[i] = m[r.names[i]]
+		if r.needNULL {
+			row[i] = replaceNULL(r.inNULL, row[i])
+		}
+	}
+	return row, nil
+}
+
+// nextRow reads up to the next element that matches the path
+// and returns it as a row.
+func (r *XMLReader) nextRow() (map[string]any, []string, error) {
+	for {
+		token, err := r.decoder.Token()
+		if err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil, nil, io.EOF
+			}
+			return nil, nil, fmt.Errorf("%w: %s", ErrInvalidXML, err)
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			r.stack = append(r.stack, t.Name.Local)
+			if r.match() {
+				row, names, err := r.element(t)
+				r.stack = r.stack[:len(r.stack)-1]
+				return row, names, err
+			}
+		case xml.EndElement:
+			if len(r.stack) > 0 {
+				r.stack = r.stack[:len(r.stack)-1]
+			}
+		}
+	}
+}
+
+// xmlChild is a child element of the row.
+type xmlChild struct {
+	text   string
+	raw    []string
+	simple bool
+}
+
+// element converts the row element to a row.
+// Attributes are prefixed with "@", and the text of the element is "#text".
+// Child elements that have only text become the text,
+// and the others (nested, repeated) are serialized as XML.
+func (r *XMLReader) element(start xml.StartElement) (map[string]any, []string, error) {
+	row := make(map[string]any)
+	names := make([]string, 0, len(start.Attr))
+	for _, attr := range start.Attr {
+		if attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" {
+			continue
+		}
+		name := "@" + attr.Name.Local
+		names = append(names, name)
+		row[name] = attr.Value
+	}
+
+	children := make(map[string]*xmlChild)
+	var text strings.Builder
+	for {
+		token, err := r.decoder.Token()
+		if err != nil {
+			return nil, nil, fmt.Errorf("%w: %s", ErrInvalidXML, err)
+		}
+		switch t := token.(type) {
+		case xml.CharData:
+			text.Write(t)
+		case xml.StartElement:
+			raw, simple, childText, err := r.serialize(t)
+			if err != nil {
+				return nil, nil, err
+			}
+			name := t.Name.Local
+			child, ok := children[name]
+			if !ok {
+				child = &xmlChild{text: childText, simple: simple}
+				children[name] = child
+				names = append(names, name)
+			} else {
+				child.simple = false
+			}
+			child.raw = append(child.raw, raw)
+		case xml.EndElement:
+			for _, name := range names {
+				if child, ok := children[name]; ok {
+					if child.simple {
+						row[name] = child.text
+					} else {
+						row[name] = strings.Join(child.raw, "")
+					}
+				}
+			}
+			if s := strings.TrimSpace(text.String()); s != "" {
+				names = append(names, "#text")
+				row["#text"] = s
+			}
+			return row, names, nil
+		}
+	}
+}
+
+// serialize reads the element to the end and returns it as XML.
+// simple is true if the element has no attributes and no child elements.
+func (r *XMLReader) serialize(start xml.StartElement) (string, bool, string, error) {
+	var buf strings.Builder
+	var text strings.Builder
+	simple := true
+	writeStart(&buf, start)
+	if len(start.Attr) > 0 {
+		simple = false
+	}
+	depth := 1
+	for depth > 0 {
+		token, err := r.decoder.Token()
+		if err != nil {
+			return "", false, "", fmt.Errorf("%w: %s", ErrInvalidXML, err)
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			simple = false
+			depth++
+			writeStart(&buf, t)
+		case xml.EndElement:
+			depth--
+			buf.WriteString("</" + t.Name.Local + ">")
+		case xml.CharData:
+			if depth == 1 {
+				text.Write(t)
+			}
+			if err := xml.EscapeText(&buf, t); err != nil {
+				return "", false, "", err
+			}
+		}
+	}
+	return buf.String(), simple, text.String(), nil
+}
+
+// writeStart writes the start tag with local names.
+func writeStart(buf *strings.Builder, start xml.StartElement) {
+	buf.WriteString("<" + start.Name.Local)
+	for _, attr := range start.Attr {
+		if attr.Name.Space == "xmlns" || attr.Name.Local == "xmlns" {
+			continue
+		}
+		buf.WriteString(" " + attr.Name.Local + `="`)
+		_ = xml.EscapeText(buf, []byte(attr.Value))
+		buf.WriteString(`"`)
+	}
+	buf.WriteString(">")
+}
@@ -0,0 +1,156 @@
+package trdsql
+
+import (
+	"errors"
+	"io"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNewXMLReader(t *testing.T) {
+	tests := []struct {
+		name        string
+		fileName    string
+		opts        *ReadOpts
+		wantNames   []string
+		wantPreRead [][]any
+		wantErr     bool
+	}{
+		{
+			name:        "default",
+			fileName:    "test.xml",
+			opts:        NewReadOpts(),
+			wantNames:   []string{"@id", "name", "price"},
+			wantPreRead: [][]any{{"1", "Orange", "50"}},
+			wantErr:     false,
+		},
+		{
+			name:      "path",
+			fileName:  "test.xml",
+			opts:      NewReadOpts(InSelector("/catalog/item"), InPreRead(3)),
+			wantNames: []string{"@id", "name", "price", "tags"},
+			wantPreRead: [][]any{
+				{"1", "Orange", "50", nil},
+				{"2", "Melon", "500", "<tags><tag>big</tag><tag>sweet</tag></tags>"},
+				{"3", "Apple & Pie", "", nil},
+			},
+			wantErr: false,
+		},
+		{
+			name:        "relativePath",
+			fileName:    "test.xml",
+			opts:        NewReadOpts(InSelector("tags"), InPreRead(3)),
+			wantNames:   []string{"tag"},
+			wantPreRead: [][]any{{"<tag>big</tag><tag>sweet</tag>"}},
+			wantErr:     false,
+		},
+		{
+			name:        "text",
+			fileName:    "test.xml",
+			opts:        NewReadOpts(InSelector("//tag"), InPreRead(3)),
+			wantNames:   []string{"#text"},
+			wantPreRead: [][]any{{"big"}, {"sweet"}},
+			wantErr:     false,
+		},
+		{
+			name:        "skip",
+			fileName:    "test.xml",
+			opts:        NewReadOpts(InSkip(2)),
+			wantNames:   []string{"@id", "name", "price"},
+			wantPreRead: [][]any{{"3", "Apple & Pie", ""}},
+			wantErr:     false,
+		},
+		{
+			name:        "inNULL",
+			fileName:    "test.xml",
+			opts:        NewReadOpts(InNeedNULL(true), InNULL("Orange")),
+			wantNames:   []string{"@id", "name", "price"},
+			wantPreRead: [][]any{{"1", nil, "50"}},
+			wantErr:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			file, err := singleFileOpen(filepath.Join(dataDir, tt.fileName))
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer file.Close()
+			got, err := NewXMLReader(file, tt.opts)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("NewXMLReader() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			if !reflect.DeepEqual(got.names, tt.wantNames) {
+				t.Errorf("NewXMLReader().names = %v, want %v", got.names, tt.wantNames)
+			}
+			if !reflect.DeepEqual(got.PreReadRow(), tt.wantPreRead) {
+				t.Errorf("NewXMLReader().PreReadRow() = %v, want %v", got.PreReadRow(), tt.wantPreRead)
+			}
+		})
+	}
+}
+
+func TestXMLReader_ReadRow(t *testing.T) {
+	const data = `<rows xmlns:x="urn:x"><row x:a="1"><b>one</b></row><row><b>two</b><c>ignored</c></row></rows>`
+	r, err := NewXMLReader(strings.NewReader(data), NewReadOpts())
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantNames := []string{"@a", "b"}
+	if !reflect.DeepEqual(r.names, wantNames) {
+		t.Errorf("XMLReader.names = %v, want %v", r.names, wantNames)
+	}
+	row := make([]any, len(r.names))
+	got, err := r.ReadRow(row)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []any{nil, "two"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("XMLReader.ReadRow() = %v, want %v", got, want)
+	}
+	if _, err := r.ReadRow(row); err != io.EOF {
+		t.Errorf("XMLReader.ReadRow() error = %v, want %v", err, io.EOF)
+	}
+}
+
+func TestXMLReaderCharset(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+		want []any
+	}{
+		{
+			name: "ISO-8859-1",
+			data: "<?xml version=\"1.0\" encoding=\"ISO-8859-1\"?><rows><row><a>caf\xe9</a></row></rows>",
+			want: []any{"café"},
+		},
+		{
+			name: "Shift_JIS",
+			data: "<?xml version=\"1.0\" encoding=\"Shift_JIS\"?><rows><row><a>\x82\xa0</a></row></rows>",
+			want: []any{"あ"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r, err := NewXMLReader(strings.NewReader(tt.data), NewReadOpts(InPreRead(1)))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := r.PreReadRow(); !reflect.DeepEqual(got, [][]any{tt.want}) {
+				t.Errorf("XMLReader.PreReadRow() = %v, want %v", got, [][]any{tt.want})
+			}
+		})
+	}
+}
+
+func TestXMLReaderInvalid(t *testing.T) {
+	_, err := NewXMLReader(strings.NewReader("<rows><row><a>1</b></row></rows>"), NewReadOpts())
+	if !errors.Is(err, ErrInvalidXML) {
+		t.Errorf("NewXMLReader() error = %v, want %v", err, ErrInvalidXML)
+	}
+}
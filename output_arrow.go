@@ -0,0 +1,125 @@
+package trdsql
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/apache/arrow-go/v18/arrow/ipc"
+	"github.com/apache/arrow-go/v18/arrow/memory"
+)
+
+// arrowBatchSize is the number of rows in a record batch.
+const arrowBatchSize = 65536
+
+// ArrowWriter writes rows as an Apache Arrow IPC file (Feather V2).
+// The schema is derived from the column types of the query result,
+// and rows are written out for each record batch.
+type ArrowWriter struct {
+	writer  *bufio.Writer
+	batch   *arrowBatch
+	file    *ipc.FileWriter
+	opts    []ipc.Option
+	err     error
+	written bool
+}
+
+// NewArrowWriter returns an ArrowWriter configured with output options.
+func NewArrowWriter(writeOpts *WriteOpts) *ArrowWriter {
+	w := &ArrowWriter{}
+	w.writer = bufio.NewWriter(writeOpts.OutStream)
+	w.opts = []ipc.Option{ipc.WithAllocator(memory.DefaultAllocator)}
+	codec, err := arrowCodec(writeOpts.OutCodec)
+	if err != nil {
+		w.err = err
+	}
+	if codec != nil {
+		w.opts = append(w.opts, codec)
+	}
+	return w
+}
+
+// arrowCodec returns the compression option of Arrow IPC.
+// The default is uncompressed.
+func arrowCodec(name string) (ipc.Option, error) {
+	switch strings.ToLower(name) {
+	case "", "none", "uncompressed":
+		return nil, nil
+	case "lz4":
+		return ipc.WithLZ4(), nil
+	case "zst", "zstd":
+		return ipc.WithZstd(), nil
+	default:
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCodec, name)
+	}
+}
+
+// PreWrite is preparation.
+// The file is created when the first row is written,
+// because the type of a column whose type name is unknown is
+// inferred from the value.
+func (w *ArrowWriter) PreWrite(columns []string, types []string) error {
+	if w.err != nil {
+		return w.err
+	}
+	if w.written {
+		return fmt.Errorf("arrow: %w", ErrMultipleResults)
+	}
+	w.written = true
+	w.batch = newArrowBatch(columns, types, arrowBatchSize)
+	return nil
+}
+
+// WriteRow is row write.
+// When the number of rows reaches the batch size, it is written to the file.
+func (w *ArrowWriter) WriteRow(values []any, columns []string) error {
+	if w.file == nil {
+		if err := w.open(values); err != nil {
+			return err
+		}
+	}
+	if err := w.batch.append(values); err != nil {
+		return err
+	}
+	if w.batch.full() {
+		return w.flush()
+	}
+	return nil
+}
+
+func (w *ArrowWriter) open(values []any) error {
+	schema := w.batch.init(values)
+	file, err := ipc.NewFileWriter(w.writer, append(w.opts, ipc.WithSchema(schema))...)
+	if err != nil {
+		return fmt.Errorf("arrow: %w", err)
+	}
+	w.file = file
+	return nil
+}
+
+// flush writes the appended rows as a record batch.
+func (w *ArrowWriter) flush() error {
+	rec := w.batch.newRecord()
+	if rec == nil {
+		return nil
+	}
+	defer rec.Release()
+	return w.file.Write(rec)
+}
+
+// PostWrite writes the remaining rows and the footer.
+func (w *ArrowWriter) PostWrite() error {
+	if w.file == nil {
+		if err := w.open(nil); err != nil {
+			return err
+		}
+	}
+	defer w.batch.release()
+	if err := w.flush(); err != nil {
+		return err
+	}
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+	return w.writer.Flush()
+}
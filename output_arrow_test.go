@@ -0,0 +1,72 @@
+package trdsql
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestArrowWriter(t *testing.T) {
+	buf := new(bytes.Buffer)
+	w := NewArrowWriter(&WriteOpts{OutStream: buf, OutCodec: "lz4"})
+	columns := []string{"id", "name", "price", "ts", "cnt"}
+	types := []string{"INTEGER", "TEXT", "REAL", "TIMESTAMP", ""}
+	if err := w.PreWrite(columns, types); err != nil {
+		t.Fatal(err)
+	}
+	ts := time.Date(2022, 1, 8, 10, 20, 30, 0, time.UTC)
+	rows := [][]any{
+		{int64(1), "Orange", 50.5, ts, 1.5},
+		{int64(2), []byte("Melon"), nil, "2022-01-08 10:20:30", 2.5},
+	}
+	for _, row := range rows {
+		if err := w.WriteRow(row, columns); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.PostWrite(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewArrowReader(bytes.NewReader(buf.Bytes()), NewReadOpts(InPreRead(2)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(r.names, columns) {
+		t.Errorf("ArrowWriter names = %v, want %v", r.names, columns)
+	}
+	wantTypes := []string{"bigint", "text", "double precision", "timestamp", "double precision"}
+	if !reflect.DeepEqual(r.types, wantTypes) {
+		t.Errorf("ArrowWriter types = %v, want %v", r.types, wantTypes)
+	}
+	want := [][]any{
+		{int64(1), "Orange", 50.5, ts, 1.5},
+		{int64(2), "Melon", nil, ts, 2.5},
+	}
+	if got := r.PreReadRow(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ArrowWriter rows = %v, want %v", got, want)
+	}
+}
+
+func TestArrowWriter_Errors(t *testing.T) {
+	t.Run("unknownCodec", func(t *testing.T) {
+		w := NewArrowWriter(&WriteOpts{OutStream: new(bytes.Buffer), OutCodec: "snappy"})
+		if err := w.PreWrite([]string{"c1"}, []string{"text"}); !errors.Is(err, ErrUnknownCodec) {
+			t.Errorf("ArrowWriter.PreWrite() error = %v, want %v", err, ErrUnknownCodec)
+		}
+	})
+	t.Run("multipleResults", func(t *testing.T) {
+		w := NewArrowWriter(&WriteOpts{OutStream: new(bytes.Buffer)})
+		if err := w.PreWrite([]string{"c1"}, []string{"text"}); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.PostWrite(); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.PreWrite([]string{"c1"}, []string{"text"}); !errors.Is(err, ErrMultipleResults) {
+			t.Errorf("ArrowWriter.PreWrite() error = %v, want %v", err, ErrMultipleResults)
+		}
+	})
+}
@@ -0,0 +1,295 @@
+package trdsql
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hamba/avro/v2"
+	"github.com/hamba/avro/v2/ocf"
+)
+
+// avroRecordName is the name of the record of the generated schema.
+const avroRecordName = "Row"
+
+// AvroWriter writes rows as an Apache Avro Object Container File.
+// The schema is generated from the column names and types of the query result.
+// All fields are nullable.
+type AvroWriter struct {
+	writer  *bufio.Writer
+	encoder *ocf.Encoder
+	codec   ocf.CodecName
+	fields  []string
+	types   []string
+	kinds   []avroKind
+	row     map[string]any
+	err     error
+	written bool
+}
+
+// avroKind is the type of the field of the generated schema.
+type avroKind int
+
+const (
+	avroUnknown avroKind = iota
+	avroString
+	avroLong
+	avroDouble
+	avroBoolean
+	avroBytes
+	avroDate
+	avroTimestamp
+)
+
+// NewAvroWriter returns an AvroWriter configured with output options.
+func NewAvroWriter(writeOpts *WriteOpts) *AvroWriter {
+	w := &AvroWriter{}
+	w.writer = bufio.NewWriter(writeOpts.OutStream)
+	codec, err := avroCodec(writeOpts.OutCodec)
+	if err != nil {
+		w.err = err
+	}
+	w.codec = codec
+	return w
+}
+
+// avroCodec returns the compression codec of Avro.
+// The default is null (uncompressed).
+func avroCodec(name string) (ocf.CodecName, error) {
+	switch strings.ToLower(name) {
+	case "", "none", "null", "uncompressed":
+		return ocf.Null, nil
+	case "deflate":
+		return ocf.Deflate, nil
+	case "snappy":
+		return ocf.Snappy, nil
+	case "zst", "zstd", "zstandard":
+		return ocf.ZStandard, nil
+	default:
+		return ocf.Null, fmt.Errorf("%w: %s", ErrUnknownCodec, name)
+	}
+}
+
+// avroColumnKind returns the kind of the field from the database type name.
+func avroColumnKind(dbType string) avroKind {
+	switch dbTypeKind(dbType) {
+	case typeInt, typeBigint:
+		return avroLong
+	case typeFloat:
+		return avroDouble
+	case typeBool:
+		return avroBoolean
+	case typeDate:
+		return avroDate
+	case typeTimestamp:
+		return avroTimestamp
+	case typeBinary:
+		return avroBytes
+	case typeUnknown:
+		return avroUnknown
+	default:
+		return avroString
+	}
+}
+
+// avroValueKind returns the kind of the field inferred from the value.
+func avroValueKind(v any) avroKind {
+	switch v.(type) {
+	case int, int32, int64:
+		return avroLong
+	case float32, float64:
+		return avroDouble
+	case bool:
+		return avroBoolean
+	case time.Time:
+		return avroTimestamp
+	default:
+		return avroString
+	}
+}
+
+// avroSchema returns the Avro schema of the kind.
+func (k avroKind) avroSchema() avro.Schema {
+	switch k {
+	case avroLong:
+		return avro.NewPrimitiveSchema(avro.Long, nil)
+	case avroDouble:
+		return avro.NewPrimitiveSchema(avro.Double, nil)
+	case avroBoolean:
+		return avro.NewPrimitiveSchema(avro.Boolean, nil)
+	case avroBytes:
+		return avro.NewPrimitiveSchema(avro.Bytes, nil)
+	case avroDate:
+		return avro.NewPrimitiveSchema(avro.Int, avro.NewPrimitiveLogicalSchema(avro.Date))
+	case avroTimestamp:
+		return avro.NewPrimitiveSchema(avro.Long, avro.NewPrimitiveLogicalSchema(avro.TimestampMicros))
+	default:
+		return avro.NewPrimitiveSchema(avro.String, nil)
+	}
+}
+
+// avroName returns a valid Avro name.
+// Characters other than [A-Za-z0-9_] are replaced with "_".
+func avroName(name string) string {
+	b := []byte(name)
+	for i, c := range b {
+		if c != '_' && (c < 'A' || c > 'Z') && (c < 'a' || c > 'z') && (c < '0' || c > '9') {
+			b[i] = '_'
+		}
+	}
+	if len(b) == 0 || (b[0] >= '0' && b[0] <= '9') {
+		b = append([]byte{'_'}, b...)
+	}
+	return string(b)
+}
+
+// PreWrite is preparation.
+// The file is created when the first row is written,
+// because the type of a column whose type name is unknown is
+// inferred from the value.
+func (w *AvroWriter) PreWrite(columns []string, types []string) error {
+	if w.err != nil {
+		return w.err
+	}
+	if w.written {
+		return fmt.Errorf("avro: %w", ErrMultipleResults)
+	}
+	w.written = true
+	w.fields = make([]string, len(columns))
+	w.kinds = make([]avroKind, len(columns))
+	already := make(map[string]bool)
+	for i, col := range columns {
+		name := avroName(col)
+		for n := 2; already[name]; n++ {
+			name = avroName(col) + "_" + strconv.Itoa(n)
+		}
+		already[name] = true
+		w.fields[i] = name
+		if i < len(types) {
+			w.kinds[i] = avroColumnKind(types[i])
+		}
+	}
+	w.row = make(map[string]any, len(columns))
+	return nil
+}
+
+// WriteRow is row write.
+func (w *AvroWriter) WriteRow(values []any, columns []string) error {
+	if w.encoder == nil {
+		if err := w.open(values); err != nil {
+			return err
+		}
+	}
+	for i, v := range values {
+		val, err := avroConvert(v, w.kinds[i])
+		if err != nil {
+			return fmt.Errorf("column %s: %w", columns[i], err)
+		}
+		w.row[w.fields[i]] = val
+	}
+	return w.encoder.Encode(w.row)
+}
+
+func (w *AvroWriter) open(values []any) error {
+	fields := make([]*avro.Field, len(w.fields))
+	for i, name := range w.fields {
+		if w.kinds[i] == avroUnknown {
+			w.kinds[i] = avroString
+			if i < len(values) && values[i] != nil {
+				w.kinds[i] = avroValueKind(values[i])
+			}
+		}
+		union, err := avro.NewUnionSchema([]avro.Schema{avro.NewNullSchema(), w.kinds[i].avroSchema()})
+		if err != nil {
+			return fmt.Errorf("avro: %w", err)
+		}
+		field, err := avro.NewField(name, union, avro.WithDefault(nil))
+		if err != nil {
+			return fmt.Errorf("avro: %w", err)
+		}
+		fields[i] = field
+	}
+	schema, err := avro.NewRecordSchema(avroRecordName, "", fields)
+	if err != nil {
+		return fmt.Errorf("avro: %w", err)
+	}
+	encoder, err := ocf.NewEncoderWithSchema(schema, w.writer, ocf.WithCodec(w.codec))
+	if err != nil {
+		return fmt.Errorf("avro: %w", err)
+	}
+	w.encoder = encoder
+	return nil
+}
+
+// avroConvert converts the database value to the Go type of the Avro field.
+func avroConvert(v any, kind avroKind) (any, error) {
+	if v == nil {
+		return nil, nil
+	}
+	switch kind {
+	case avroLong:
+		switch t := v.(type) {
+		case int64:
+			return t, nil
+		case int:
+			return int64(t), nil
+		case int32:
+			return int64(t), nil
+		}
+		i, err := strconv.ParseInt(ValString(v), 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v to integer", ErrUnableConvert, v)
+		}
+		return i, nil
+	case avroDouble:
+		switch t := v.(type) {
+		case float64:
+			return t, nil
+		case float32:
+			return float64(t), nil
+		case int64:
+			return float64(t), nil
+		}
+		f, err := strconv.ParseFloat(ValString(v), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v to float", ErrUnableConvert, v)
+		}
+		return f, nil
+	case avroBoolean:
+		switch t := v.(type) {
+		case bool:
+			return t, nil
+		case int64:
+			return t != 0, nil
+		}
+		b, err := strconv.ParseBool(ValString(v))
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v to bool", ErrUnableConvert, v)
+		}
+		return b, nil
+	case avroDate, avroTimestamp:
+		return valueTime(v)
+	case avroBytes:
+		if b, ok := v.([]byte); ok {
+			return b, nil
+		}
+		return []byte(ValString(v)), nil
+	default:
+		return ValString(v), nil
+	}
+}
+
+// PostWrite writes the remaining rows.
+func (w *AvroWriter) PostWrite() error {
+	if w.encoder == nil {
+		if err := w.open(nil); err != nil {
+			return err
+		}
+	}
+	if err := w.encoder.Close(); err != nil {
+		return err
+	}
+	return w.writer.Flush()
+}
@@ -0,0 +1,70 @@
+package trdsql
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestAvroWriter(t *testing.T) {
+	buf := new(bytes.Buffer)
+	w := NewAvroWriter(&WriteOpts{OutStream: buf, OutCodec: "deflate"})
+	columns := []string{"id", "name", "price", "ts", "count(*)", "count(*)"}
+	types := []string{"INTEGER", "TEXT", "REAL", "TIMESTAMP", "", ""}
+	if err := w.PreWrite(columns, types); err != nil {
+		t.Fatal(err)
+	}
+	ts := time.Date(2022, 1, 8, 10, 20, 30, 0, time.UTC)
+	rows := [][]any{
+		{int64(1), "Orange", 50.5, ts, int64(3), nil},
+		{"2", []byte("Melon"), nil, "2022-01-08 10:20:30", int64(3), nil},
+	}
+	for _, row := range rows {
+		if err := w.WriteRow(row, columns); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.PostWrite(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewAvroReader(bytes.NewReader(buf.Bytes()), NewReadOpts(InPreRead(2)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantNames := []string{"id", "name", "price", "ts", "count___", "count____2"}
+	if !reflect.DeepEqual(r.names, wantNames) {
+		t.Errorf("AvroWriter names = %v, want %v", r.names, wantNames)
+	}
+	wantTypes := []string{"bigint", "text", "double precision", "timestamp", "bigint", "text"}
+	if !reflect.DeepEqual(r.types, wantTypes) {
+		t.Errorf("AvroWriter types = %v, want %v", r.types, wantTypes)
+	}
+	want := [][]any{
+		{int64(1), "Orange", 50.5, ts, int64(3), nil},
+		{int64(2), "Melon", nil, ts, int64(3), nil},
+	}
+	if got := r.PreReadRow(); !reflect.DeepEqual(got, want) {
+		t.Errorf("AvroWriter rows = %v, want %v", got, want)
+	}
+}
+
+func TestAvroWriter_Errors(t *testing.T) {
+	t.Run("unknownCodec", func(t *testing.T) {
+		w := NewAvroWriter(&WriteOpts{OutStream: new(bytes.Buffer), OutCodec: "lz4"})
+		if err := w.PreWrite([]string{"c1"}, []string{"text"}); !errors.Is(err, ErrUnknownCodec) {
+			t.Errorf("AvroWriter.PreWrite() error = %v, want %v", err, ErrUnknownCodec)
+		}
+	})
+	t.Run("unableConvert", func(t *testing.T) {
+		w := NewAvroWriter(&WriteOpts{OutStream: new(bytes.Buffer)})
+		if err := w.PreWrite([]string{"c1"}, []string{"int"}); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.WriteRow([]any{"abc"}, []string{"c1"}); !errors.Is(err, ErrUnableConvert) {
+			t.Errorf("AvroWriter.WriteRow() error = %v, want %v", err, ErrUnableConvert)
+		}
+	})
+}
@@ -0,0 +1,363 @@
+package trdsql
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	runewidth "github.com/mattn/go-runewidth"
+)
+
+// DocWriter renders rows as a table of the documentation formats
+// (AsciiDoc, reStructuredText, LaTeX and Org-mode).
+// The rows are buffered to compute the column widths,
+// which are display widths, so East Asian wide characters are 2.
+// The values are escaped for the syntax of the format.
+type DocWriter struct {
+	writer   *bufio.Writer
+	format   Format
+	rstStyle string
+	outNULL  string
+	header   []string
+	right    []bool
+	guess    []bool
+	rows     [][]string
+	needNULL bool
+}
+
+// NewDocWriter returns a DocWriter of the format(ADOC, RST, LATEX or ORG).
+func NewDocWriter(writeOpts *WriteOpts, format Format) *DocWriter {
+	w := &DocWriter{}
+	w.writer = bufio.NewWriter(writeOpts.OutStream)
+	w.format = format
+	w.rstStyle = writeOpts.OutRSTStyle
+	w.needNULL = writeOpts.OutNeedNULL
+	w.outNULL = writeOpts.OutNULL
+	return w
+}
+
+// PreWrite is preparation.
+func (w *DocWriter) PreWrite(columns []string, types []string) error {
+	if w.format == RST {
+		switch w.rstStyle {
+		case "", "grid", "simple":
+		default:
+			return fmt.Errorf("invalid rst style %q [ grid | simple ]", w.rstStyle)
+		}
+	}
+	w.header = make([]string, len(columns))
+	for i, column := range columns {
+		w.header[i] = w.escape(column)
+	}
+	w.right = make([]bool, len(columns))
+	w.guess = make([]bool, len(columns))
+	for i := range columns {
+		if i < len(types) {
+			w.right[i] = isNumericType(types[i])
+			w.guess[i] = types[i] == ""
+		}
+	}
+	w.rows = nil
+	return nil
+}
+
+// WriteRow is Addition to array.
+func (w *DocWriter) WriteRow(values []any, columns []string) error {
+	record := make([]string, len(values))
+	for i, col := range values {
+		if i < len(w.guess) && w.guess[i] && col != nil {
+			w.right[i] = isNumericValue(col)
+			w.guess[i] = false
+		}
+		str := ValString(col)
+		if col == nil && w.needNULL {
+			str = w.outNULL
+		}
+		record[i] = w.escape(str)
+	}
+	w.rows = append(w.rows, record)
+	return nil
+}
+
+// PostWrite is actual output.
+func (w *DocWriter) PostWrite() error {
+	var table string
+	switch w.format {
+	case ADOC:
+		table = w.asciiDoc()
+	case RST:
+		if w.rstStyle == "simple" {
+			table = w.rstSimple()
+		} else {
+			table = w.rstGrid()
+		}
+	case LATEX:
+		table = w.latex()
+	case ORG:
+		table = w.org()
+	}
+	if _, err := w.writer.WriteString(table); err != nil {
+		return err
+	}
+	w.rows = nil
+	return w.writer.Flush()
+}
+
+// escape escapes the value for the format.
+func (w *DocWriter) escape(str string) string {
+	str = strings.ReplaceAll(str, "\r\n", "\n")
+	switch w.format {
+	case ADOC:
+		return strings.ReplaceAll(strings.ReplaceAll(str, `|`, `\|`), "\n", " +\n")
+	case RST:
+		str = rstEscaper.Replace(str)
+		if w.rstStyle == "simple" {
+			str = strings.ReplaceAll(str, "\n", " ")
+		}
+		return str
+	case LATEX:
+		return latexEscaper.Replace(strings.ReplaceAll(str, "\n", " "))
+	case ORG:
+		return strings.ReplaceAll(strings.ReplaceAll(str, `|`, `\vert{}`), "\n", " ")
+	}
+	return str
+}
+
+// rstEscaper escapes the inline markup characters of reStructuredText.
+var rstEscaper = strings.NewReplacer(`\`, `\\`, `*`, `\*`, "`", "\\`", `|`, `\|`, `_`, `\_`)
+
+// latexEscaper escapes the special characters of LaTeX.
+var latexEscaper = strings.NewReplacer(
+	`\`, `\textbackslash{}`,
+	`&`, `\&`,
+	`%`, `\%`,
+	`$`, `\$`,
+	`#`, `\#`,
+	`_`, `\_`,
+	`{`, `\{`,
+	`}`, `\}`,
+	`~`, `\textasciitilde{}`,
+	`^`, `\textasciicircum{}`,
+	`|`, `\textbar{}`,
+	`<`, `\textless{}`,
+	`>`, `\textgreater{}`,
+)
+
+// widths returns the maximum display widths of the columns.
+// The width of a multi-line value is the width of the longest line.
+func (w *DocWriter) widths() []int {
+	widths := make([]int, len(w.header))
+	for _, record := range append([][]string{w.header}, w.rows...) {
+		for i, str := range record {
+			if i >= len(widths) {
+				break
+			}
+			for _, line := range strings.Split(str, "\n") {
+				widths[i] = max(widths[i], runewidth.StringWidth(line))
+			}
+		}
+	}
+	return widths
+}
+
+// docPad returns the string padded to the display width.
+func docPad(str string, width int) string {
+	return str + strings.Repeat(" ", max(width-runewidth.StringWidth(str), 0))
+}
+
+// asciiDoc returns the AsciiDoc table.
+//
+//	[options="header"]
+//	|===
+//	| id | name
+//	| 1  | Orange
+//	|===
+func (w *DocWriter) asciiDoc() string {
+	widths := w.widths()
+	var b strings.Builder
+	b.WriteString("[options=\"header\"]\n|===\n")
+	for _, record := range append([][]string{w.header}, w.rows...) {
+		var cells []string
+		for i, str := range record {
+			if i < len(widths) && i < len(record)-1 && !strings.Contains(str, "\n") {
+				str = docPad(str, widths[i])
+			}
+			cells = append(cells, "| "+str)
+		}
+		b.WriteString(strings.TrimRight(strings.Join(cells, " "), " ") + "\n")
+	}
+	b.WriteString("|===\n")
+	return b.String()
+}
+
+// rstGrid returns the reStructuredText grid table.
+//
+//	+----+--------+
+//	| id | name   |
+//	+====+========+
+//	| 1  | Orange |
+//	+----+--------+
+func (w *DocWriter) rstGrid() string {
+	widths := w.widths()
+	border := func(c string) string {
+		var b strings.Builder
+		b.WriteString("+")
+		for _, width := range widths {
+			b.WriteString(strings.Repeat(c, width+2) + "+")
+		}
+		return b.String() + "\n"
+	}
+	row := func(record []string) string {
+		cells := make([][]string, len(widths))
+		height := 1
+		for i := range widths {
+			if i < len(record) {
+				cells[i] = strings.Split(record[i], "\n")
+			}
+			height = max(height, len(cells[i]))
+		}
+		var b strings.Builder
+		for n := range height {
+			b.WriteString("|")
+			for i, width := range widths {
+				line := ""
+				if n < len(cells[i]) {
+					line = cells[i][n]
+				}
+				b.WriteString(" " + docPad(line, width) + " |")
+			}
+			b.WriteString("\n")
+		}
+		return b.String()
+	}
+	var b strings.Builder
+	b.WriteString(border("-"))
+	b.WriteString(row(w.header))
+	b.WriteString(border("="))
+	for _, record := range w.rows {
+		b.WriteString(row(record))
+		b.WriteString(border("-"))
+	}
+	return b.String()
+}
+
+// rstSimple returns the reStructuredText simple table.
+// An empty comment("..") is written if the first column is empty,
+// because it means a continuation line.
+//
+//	==  ======
+//	id  name
+//	==  ======
+//	1   Orange
+//	==  ======
+func (w *DocWriter) rstSimple() string {
+	for _, record := range w.rows {
+		if len(record) > 0 && record[0] == "" {
+			record[0] = ".."
+		}
+	}
+	widths := w.widths()
+	for i := range widths {
+		widths[i] = max(widths[i], 1)
+	}
+	border := make([]string, len(widths))
+	for i, width := range widths {
+		border[i] = strings.Repeat("=", width)
+	}
+	line := func(record []string) string {
+		cells := make([]string, len(widths))
+		for i, width := range widths {
+			if i < len(record) {
+				cells[i] = docPad(record[i], width)
+			}
+		}
+		return strings.TrimRight(strings.Join(cells, "  "), " ") + "\n"
+	}
+	var b strings.Builder
+	b.WriteString(line(border))
+	b.WriteString(line(w.header))
+	b.WriteString(line(border))
+	for _, record := range w.rows {
+		b.WriteString(line(record))
+	}
+	b.WriteString(line(border))
+	return b.String()
+}
+
+// latex returns the LaTeX tabular.
+// The columns of the numeric types are right-aligned
+// (if the type is unknown, it is determined by the first non-NULL value).
+//
+//	\begin{tabular}{rl}
+//	\hline
+//	id & name   \\
+//	\hline
+//	1  & Orange \\
+//	\hline
+//	\end{tabular}
+func (w *DocWriter) latex() string {
+	widths := w.widths()
+	var spec strings.Builder
+	for _, right := range w.right {
+		if right {
+			spec.WriteByte('r')
+		} else {
+			spec.WriteByte('l')
+		}
+	}
+	row := func(record []string) string {
+		cells := make([]string, len(widths))
+		for i, width := range widths {
+			if i < len(record) {
+				cells[i] = docPad(record[i], width)
+			}
+		}
+		return strings.Join(cells, " & ") + ` \\` + "\n"
+	}
+	var b strings.Builder
+	b.WriteString(`\begin{tabular}{` + spec.String() + "}\n")
+	b.WriteString("\\hline\n")
+	b.WriteString(row(w.header))
+	b.WriteString("\\hline\n")
+	for _, record := range w.rows {
+		b.WriteString(row(record))
+	}
+	b.WriteString("\\hline\n")
+	b.WriteString(`\end{tabular}` + "\n")
+	return b.String()
+}
+
+// org returns the Org-mode table.
+//
+//	| id | name   |
+//	|----+--------|
+//	| 1  | Orange |
+func (w *DocWriter) org() string {
+	widths := w.widths()
+	row := func(record []string) string {
+		var b strings.Builder
+		b.WriteString("|")
+		for i, width := range widths {
+			str := ""
+			if i < len(record) {
+				str = record[i]
+			}
+			b.WriteString(" " + docPad(str, width) + " |")
+		}
+		return b.String() + "\n"
+	}
+	var b strings.Builder
+	b.WriteString(row(w.header))
+	b.WriteString("|")
+	for i, width := range widths {
+		if i > 0 {
+			b.WriteString("+")
+		}
+		b.WriteString(strings.Repeat("-", width+2))
+	}
+	b.WriteString("|\n")
+	for _, record := range w.rows {
+		b.WriteString(row(record))
+	}
+	return b.String()
+}
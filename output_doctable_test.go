@@ -0,0 +1,124 @@
+package trdsql
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestDocWriter(t *testing.T) {
+	tests := []struct {
+		name   string
+		format Format
+		opts   *WriteOpts
+		want   string
+	}{
+		{
+			name:   "asciiDoc",
+			format: ADOC,
+			opts:   &WriteOpts{},
+			want: "[options=\"header\"]\n" +
+				"|===\n" +
+				"| id | name   | note\n" +
+				"| 1  | メロン | a\\|b_c\n" +
+				"| 2  |        | x +\ny\n" +
+				"|===\n",
+		},
+		{
+			name:   "rstGrid",
+			format: RST,
+			opts:   &WriteOpts{OutRSTStyle: "grid"},
+			want: "+----+--------+---------+\n" +
+				"| id | name   | note    |\n" +
+				"+====+========+=========+\n" +
+				"| 1  | メロン | a\\|b\\_c |\n" +
+				"+----+--------+---------+\n" +
+				"| 2  |        | x       |\n" +
+				"|    |        | y       |\n" +
+				"+----+--------+---------+\n",
+		},
+		{
+			name:   "rstSimple",
+			format: RST,
+			opts:   &WriteOpts{OutRSTStyle: "simple", OutNeedNULL: true, OutNULL: "NULL"},
+			want: "==  ======  =======\n" +
+				"id  name    note\n" +
+				"==  ======  =======\n" +
+				"1   メロン  a\\|b\\_c\n" +
+				"2   NULL    x y\n" +
+				"==  ======  =======\n",
+		},
+		{
+			name:   "latex",
+			format: LATEX,
+			opts:   &WriteOpts{},
+			want: "\\begin{tabular}{rll}\n" +
+				"\\hline\n" +
+				"id & name   & note            \\\\\n" +
+				"\\hline\n" +
+				"1  & メロン & a\\textbar{}b\\_c \\\\\n" +
+				"2  &        & x y             \\\\\n" +
+				"\\hline\n" +
+				"\\end{tabular}\n",
+		},
+		{
+			name:   "org",
+			format: ORG,
+			opts:   &WriteOpts{},
+			want: "| id | name   | note        |\n" +
+				"|----+--------+-------------|\n" +
+				"| 1  | メロン | a\\vert{}b_c |\n" +
+				"| 2  |        | x y         |\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			tt.opts.OutStream = buf
+			w := NewDocWriter(tt.opts, tt.format)
+			names := []string{"id", "name", "note"}
+			if err := w.PreWrite(names, []string{"int", "text", "text"}); err != nil {
+				t.Fatal(err)
+			}
+			rows := [][]any{
+				{1, "メロン", "a|b_c"},
+				{2, nil, "x\ny"},
+			}
+			for _, row := range rows {
+				if err := w.WriteRow(row, names); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := w.PostWrite(); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("DocWriter = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDocWriterRSTSimpleEmpty(t *testing.T) {
+	buf := new(bytes.Buffer)
+	w := NewDocWriter(&WriteOpts{OutRSTStyle: "simple", OutStream: buf}, RST)
+	if err := w.PreWrite([]string{"a", "b"}, []string{"text", "text"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WriteRow([]any{"", "x"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.PostWrite(); err != nil {
+		t.Fatal(err)
+	}
+	want := "==  =\na   b\n==  =\n..  x\n==  =\n"
+	if got := buf.String(); got != want {
+		t.Errorf("DocWriter = %q, want %q", got, want)
+	}
+}
+
+func TestDocWriterInvalidRSTStyle(t *testing.T) {
+	w := NewDocWriter(&WriteOpts{OutRSTStyle: "table", OutStream: new(bytes.Buffer)}, RST)
+	if err := w.PreWrite([]string{"a"}, []string{"text"}); err == nil {
+		t.Error("DocWriter.PreWrite() error = nil, want error")
+	}
+}
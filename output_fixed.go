@@ -0,0 +1,272 @@
+package trdsql
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	runewidth "github.com/mattn/go-runewidth"
+)
+
+// ErrOverflow is returned when the value exceeds the column width.
+var ErrOverflow = errors.New("value exceeds the column width")
+
+// fixedColumn is the width and the alignment of the output column.
+// width 0 is computed from the data.
+// auto is true if numeric columns are aligned to the right.
+// guess is true if numeric is determined by the first non-NULL value.
+type fixedColumn struct {
+	width   int
+	right   bool
+	auto    bool
+	numeric bool
+	guess   bool
+}
+
+// FixedWriter writes rows as fixed-width records.
+// The column widths are computed from the data, or specified by OutFixedWidths.
+// The widths are display widths, so East Asian wide characters are 2,
+// and a wide character that does not fit is replaced with the padding.
+// Values that exceed the specified width are truncated with OutFixedOverflow "truncate",
+// and an error is returned with "error".
+// With "auto" (default), text values are truncated
+// and an error is returned for numeric values, which cannot be truncated safely.
+// Newlines, tabs and other control characters are replaced with spaces
+// to keep one record per line.
+// If any width is computed from the data, all rows are buffered until PostWrite.
+type FixedWriter struct {
+	writer   *bufio.Writer
+	outNULL  string
+	spec     string
+	align    string
+	overflow string
+	columns  []fixedColumn
+	header   []string
+	rows     [][]string
+	pad      string
+	buffered bool
+	outHead  bool
+	needNULL bool
+}
+
+// NewFixedWriter returns a FixedWriter configured with output options.
+func NewFixedWriter(writeOpts *WriteOpts) *FixedWriter {
+	w := &FixedWriter{}
+	w.writer = bufio.NewWriter(writeOpts.OutStream)
+	w.spec = writeOpts.OutFixedWidths
+	w.align = writeOpts.OutFixedAlign
+	w.overflow = writeOpts.OutFixedOverflow
+	w.pad = writeOpts.OutFixedPad
+	if w.pad == "" {
+		w.pad = " "
+	}
+	w.outHead = writeOpts.OutHeader
+	w.needNULL = writeOpts.OutNeedNULL
+	w.outNULL = writeOpts.OutNULL
+	return w
+}
+
+// PreWrite is preparation.
+func (w *FixedWriter) PreWrite(columns []string, types []string) error {
+	if utf8.RuneCountInString(w.pad) != 1 || runewidth.StringWidth(w.pad) != 1 {
+		return fmt.Errorf("invalid padding character %q", w.pad)
+	}
+	switch w.align {
+	case "", "left", "right", "auto":
+	default:
+		return fmt.Errorf("invalid alignment %q [ left | right | auto ]", w.align)
+	}
+	switch w.overflow {
+	case "", "auto", "truncate", "error":
+	default:
+		return fmt.Errorf("invalid overflow %q [ auto | truncate | error ]", w.overflow)
+	}
+	widths, aligns, err := parseFixedWidths(w.spec)
+	if err != nil {
+		return err
+	}
+	if len(widths) > len(columns) {
+		return fmt.Errorf("%w: %d widths for %d columns", ErrInvalidWidths, len(widths), len(columns))
+	}
+	w.columns = make([]fixedColumn, len(columns))
+	w.buffered = false
+	for i := range w.columns {
+		column := &w.columns[i]
+		align := w.align
+		if i < len(widths) {
+			column.width = widths[i]
+			if aligns[i] != "" {
+				align = aligns[i]
+			}
+		}
+		column.numeric = isNumericType(types[i])
+		column.guess = types[i] == ""
+		switch align {
+		case "right":
+			column.right = true
+		case "auto":
+			column.auto = true
+			column.right = column.numeric
+		}
+		if column.width == 0 {
+			w.buffered = true
+		}
+	}
+	w.header = make([]string, len(columns))
+	for i, column := range columns {
+		w.header[i] = fixedString(column)
+	}
+	w.rows = nil
+	if w.buffered {
+		return nil
+	}
+	if w.outHead {
+		return w.writeRecord(w.header, true)
+	}
+	return nil
+}
+
+// parseFixedWidths parses the specification of the column widths.
+// Each width is separated by ',' and can be suffixed with 'L'(left) or 'R'(right),
+// and '*' is computed from the data.
+//
+//	8,12R,*
+//
+// It returns the widths (0 is '*') and the alignments ("left", "right" or "" if not specified).
+func parseFixedWidths(spec string) ([]int, []string, error) {
+	if strings.TrimSpace(spec) == "" {
+		return nil, nil, nil
+	}
+	var widths []int
+	var aligns []string
+	for _, field := range strings.Split(spec, ",") {
+		field = strings.TrimSpace(field)
+		align := ""
+		switch {
+		case strings.HasSuffix(field, "R"), strings.HasSuffix(field, "r"):
+			align = "right"
+			field = field[:len(field)-1]
+		case strings.HasSuffix(field, "L"), strings.HasSuffix(field, "l"):
+			align = "left"
+			field = field[:len(field)-1]
+		}
+		width := 0
+		if field != "*" {
+			var err error
+			width, err = strconv.Atoi(field)
+			if err != nil || width < 1 {
+				return nil, nil, fmt.Errorf("%w: %q", ErrInvalidWidths, spec)
+			}
+		}
+		widths = append(widths, width)
+		aligns = append(aligns, align)
+	}
+	return widths, aligns, nil
+}
+
+// isNumericValue returns true if the value is a number.
+func isNumericValue(v any) bool {
+	switch v.(type) {
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64, float32, float64:
+		return true
+	}
+	return false
+}
+
+// WriteRow is row write.
+func (w *FixedWriter) WriteRow(values []any, columns []string) error {
+	record := make([]string, len(values))
+	for i, col := range values {
+		if i < len(w.columns) && w.columns[i].guess && col != nil {
+			column := &w.columns[i]
+			column.numeric = isNumericValue(col)
+			column.guess = false
+			if column.auto {
+				column.right = column.numeric
+			}
+		}
+		str := ValString(col)
+		if col == nil && w.needNULL {
+			str = w.outNULL
+		}
+		record[i] = fixedString(str)
+	}
+	if w.buffered {
+		w.rows = append(w.rows, record)
+		return nil
+	}
+	return w.writeRecord(record, false)
+}
+
+// fixedString returns the string in which control characters are replaced with spaces.
+// CRLF is one space.
+func fixedString(str string) string {
+	str = strings.ReplaceAll(str, "\r\n", "\n")
+	return strings.Map(func(r rune) rune {
+		if unicode.IsControl(r) {
+			return ' '
+		}
+		return r
+	}, str)
+}
+
+// writeRecord writes a record padded to the column widths.
+// header is true if the record is the header, which is not numeric.
+func (w *FixedWriter) writeRecord(record []string, header bool) error {
+	for i, str := range record {
+		if i >= len(w.columns) {
+			break
+		}
+		column := w.columns[i]
+		width := runewidth.StringWidth(str)
+		if width > column.width {
+			if w.overflow == "error" || (w.overflow != "truncate" && column.numeric && !header) {
+				return fmt.Errorf("%w: %q(%d) > %d", ErrOverflow, str, width, column.width)
+			}
+			str = runewidth.Truncate(str, column.width, "")
+			width = runewidth.StringWidth(str)
+		}
+		padding := strings.Repeat(w.pad, column.width-width)
+		if column.right {
+			str = padding + str
+		} else {
+			str = str + padding
+		}
+		if _, err := w.writer.WriteString(str); err != nil {
+			return err
+		}
+	}
+	return w.writer.WriteByte('\n')
+}
+
+// PostWrite writes the buffered rows with the computed widths and flushes.
+func (w *FixedWriter) PostWrite() error {
+	if w.buffered {
+		computed := make([]bool, len(w.columns))
+		for i := range w.columns {
+			computed[i] = w.columns[i].width == 0
+		}
+		records := w.rows
+		if w.outHead {
+			records = append([][]string{w.header}, records...)
+		}
+		for _, record := range records {
+			for i, str := range record {
+				if i < len(computed) && computed[i] && w.columns[i].width < runewidth.StringWidth(str) {
+					w.columns[i].width = runewidth.StringWidth(str)
+				}
+			}
+		}
+		for n, record := range records {
+			if err := w.writeRecord(record, w.outHead && n == 0); err != nil {
+				return err
+			}
+		}
+		w.rows = nil
+	}
+	return w.writer.Flush()
+}
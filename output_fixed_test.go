@@ -0,0 +1,135 @@
+package trdsql
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+)
+
+func TestFixedWriter(t *testing.T) {
+	names := []string{"id", "name", "price"}
+	types := []string{"int", "text", ""}
+	rows := [][]any{
+		{1, "Orange", 50},
+		{2, "メロン", nil},
+		{3, "Apple pie", 100},
+	}
+	tests := []struct {
+		name    string
+		opts    *WriteOpts
+		want    string
+		wantErr error
+	}{
+		{
+			name: "computed",
+			opts: &WriteOpts{},
+			want: "1Orange   50 \n" +
+				"2メロン      \n" +
+				"3Apple pie100\n",
+		},
+		{
+			name: "header",
+			opts: &WriteOpts{OutHeader: true, OutNeedNULL: true, OutNULL: "-"},
+			want: "idname     price\n" +
+				"1 Orange   50   \n" +
+				"2 メロン   -    \n" +
+				"3 Apple pie100  \n",
+		},
+		{
+			name: "auto",
+			opts: &WriteOpts{OutFixedAlign: "auto"},
+			want: "1Orange    50\n" +
+				"2メロン      \n" +
+				"3Apple pie100\n",
+		},
+		{
+			name: "widths",
+			opts: &WriteOpts{OutFixedWidths: "3R,5,4R", OutFixedPad: "0"},
+			want: "001Orang0050\n" +
+				"002メロ00000\n" +
+				"003Apple0100\n",
+		},
+		{
+			name: "partialWidths",
+			opts: &WriteOpts{OutFixedWidths: "2,*", OutFixedAlign: "right"},
+			want: " 1   Orange 50\n" +
+				" 2   メロン   \n" +
+				" 3Apple pie100\n",
+		},
+		{
+			name:    "overflow",
+			opts:    &WriteOpts{OutFixedWidths: "1,5,3", OutFixedOverflow: "error"},
+			wantErr: ErrOverflow,
+		},
+		{
+			name:    "numericOverflow",
+			opts:    &WriteOpts{OutFixedWidths: "1,5,2"},
+			wantErr: ErrOverflow,
+		},
+		{
+			name: "truncate",
+			opts: &WriteOpts{OutFixedWidths: "1,5,2", OutFixedOverflow: "truncate"},
+			want: "1Orang50\n" +
+				"2メロ   \n" +
+				"3Apple10\n",
+		},
+		{
+			name:    "tooManyWidths",
+			opts:    &WriteOpts{OutFixedWidths: "1,2,3,4"},
+			wantErr: ErrInvalidWidths,
+		},
+		{
+			name:    "invalidWidths",
+			opts:    &WriteOpts{OutFixedWidths: "1,x"},
+			wantErr: ErrInvalidWidths,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			tt.opts.OutStream = buf
+			w := NewFixedWriter(tt.opts)
+			err := w.PreWrite(names, types)
+			for _, row := range rows {
+				if err != nil {
+					break
+				}
+				err = w.WriteRow(row, names)
+			}
+			if err == nil {
+				err = w.PostWrite()
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("FixedWriter error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("FixedWriter =\n%q, want\n%q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFixedWriterControl(t *testing.T) {
+	buf := new(bytes.Buffer)
+	w := NewFixedWriter(&WriteOpts{OutStream: buf, OutFixedWidths: "6,4"})
+	names := []string{"a", "b"}
+	if err := w.PreWrite(names, []string{"text", "text"}); err != nil {
+		t.Fatal(err)
+	}
+	for _, row := range [][]any{{"x\ny", "1\t2"}, {"a\r\nb\rc", "z"}} {
+		if err := w.WriteRow(row, names); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.PostWrite(); err != nil {
+		t.Fatal(err)
+	}
+	want := "x y   1 2 \n" +
+		"a b c z   \n"
+	if got := buf.String(); got != want {
+		t.Errorf("FixedWriter =\n%q, want\n%q", got, want)
+	}
+}
@@ -0,0 +1,167 @@
+package trdsql
+
+import (
+	"bufio"
+	"html"
+	"strings"
+)
+
+// htmlDocumentHead is the beginning of the HTML document(OutHTMLDocument).
+var htmlDocumentHead = `<!DOCTYPE html>
+<html>
+<head>
+<meta charset="UTF-8">
+<title>` + AppName + `</title>
+<style>
+table { border-collapse: collapse; }
+th, td { border: 1px solid #ccc; padding: 4px 8px; }
+th { background-color: #f0f0f0; }
+</style>
+</head>
+<body>
+`
+
+// htmlDocumentTail is the end of the HTML document(OutHTMLDocument).
+const htmlDocumentTail = "</body>\n</html>\n"
+
+// HTMLWriter writes rows as an HTML <table>.
+// The column names are the <thead>, and each row is written as it arrives.
+// The columns of the numeric types are right-aligned
+// (if the type is unknown, it is determined by the first non-NULL value),
+// and NULL cells have the class "null" and are grayed out.
+// Styles are inline so that the table can be pasted into an email.
+// With OutHTMLDocument, the tables of multiple queries are written in one document,
+// which is closed by Finish.
+//
+//	<table>
+//	<thead>
+//	<tr><th>id</th><th>name</th></tr>
+//	</thead>
+//	<tbody>
+//	<tr><td style="text-align: right">1</td><td>Orange</td></tr>
+//	</tbody>
+//	</table>
+type HTMLWriter struct {
+	writer   *bufio.Writer
+	outNULL  string
+	right    []bool
+	guess    []bool
+	document bool
+	needNULL bool
+	started  bool
+}
+
+// NewHTMLWriter returns an HTMLWriter configured with output options.
+func NewHTMLWriter(writeOpts *WriteOpts) *HTMLWriter {
+	w := &HTMLWriter{}
+	w.writer = bufio.NewWriter(writeOpts.OutStream)
+	w.document = writeOpts.OutHTMLDocument
+	w.needNULL = writeOpts.OutNeedNULL
+	w.outNULL = writeOpts.OutNULL
+	return w
+}
+
+// PreWrite writes the start of the table and the header.
+func (w *HTMLWriter) PreWrite(columns []string, types []string) error {
+	w.right = make([]bool, len(columns))
+	w.guess = make([]bool, len(columns))
+	for i := range columns {
+		if i < len(types) {
+			w.right[i] = isNumericType(types[i])
+			w.guess[i] = types[i] == ""
+		}
+	}
+	if w.document && !w.started {
+		w.started = true
+		if _, err := w.writer.WriteString(htmlDocumentHead); err != nil {
+			return err
+		}
+	}
+	if _, err := w.writer.WriteString("<table>\n<thead>\n<tr>"); err != nil {
+		return err
+	}
+	for i, column := range columns {
+		if err := w.writeCell("th", i, htmlEscape(column), ""); err != nil {
+			return err
+		}
+	}
+	_, err := w.writer.WriteString("</tr>\n</thead>\n<tbody>\n")
+	return err
+}
+
+// WriteRow is row write to HTML.
+// NULL is an empty cell unless OutNeedNULL is set.
+func (w *HTMLWriter) WriteRow(values []any, columns []string) error {
+	if _, err := w.writer.WriteString("<tr>"); err != nil {
+		return err
+	}
+	for i, col := range values {
+		if i < len(w.guess) && w.guess[i] && col != nil {
+			w.right[i] = isNumericValue(col)
+			w.guess[i] = false
+		}
+		class := ""
+		str := ValString(col)
+		if col == nil {
+			class = "null"
+			str = ""
+			if w.needNULL {
+				str = w.outNULL
+			}
+		}
+		if err := w.writeCell("td", i, htmlEscape(str), class); err != nil {
+			return err
+		}
+	}
+	_, err := w.writer.WriteString("</tr>\n")
+	return err
+}
+
+// writeCell writes a cell with the style of the column.
+func (w *HTMLWriter) writeCell(tag string, i int, text string, class string) error {
+	var style []string
+	if i < len(w.right) && w.right[i] {
+		style = append(style, "text-align: right")
+	}
+	if class == "null" {
+		style = append(style, "color: gray", "font-style: italic")
+	}
+	var b strings.Builder
+	b.WriteString("<" + tag)
+	if class != "" {
+		b.WriteString(` class="` + class + `"`)
+	}
+	if len(style) > 0 {
+		b.WriteString(` style="` + strings.Join(style, "; ") + `"`)
+	}
+	b.WriteString(">" + text + "</" + tag + ">")
+	_, err := w.writer.WriteString(b.String())
+	return err
+}
+
+// htmlEscape escapes the text of the cell, and newlines are <br>.
+func htmlEscape(str string) string {
+	str = html.EscapeString(str)
+	str = strings.ReplaceAll(str, "\r\n", "\n")
+	return strings.ReplaceAll(str, "\n", "<br>")
+}
+
+// PostWrite writes the end of the table and flushes.
+func (w *HTMLWriter) PostWrite() error {
+	if _, err := w.writer.WriteString("</tbody>\n</table>\n"); err != nil {
+		return err
+	}
+	return w.writer.Flush()
+}
+
+// Finish writes the end of the HTML document(OutHTMLDocument).
+func (w *HTMLWriter) Finish() error {
+	if !w.started {
+		return nil
+	}
+	w.started = false
+	if _, err := w.writer.WriteString(htmlDocumentTail); err != nil {
+		return err
+	}
+	return w.writer.Flush()
+}
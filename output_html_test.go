@@ -0,0 +1,105 @@
+package trdsql
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestHTMLWriter(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  *WriteOpts
+		types []string
+		want  string
+	}{
+		{
+			name:  "default",
+			opts:  &WriteOpts{},
+			types: []string{"int", "text", "text"},
+			want: "<table>\n<thead>\n" +
+				`<tr><th style="text-align: right">id</th><th>name</th><th>note</th></tr>` + "\n" +
+				"</thead>\n<tbody>\n" +
+				`<tr><td style="text-align: right">1</td><td>Apple &amp; &lt;Pie&gt;</td><td class="null" style="color: gray; font-style: italic"></td></tr>` + "\n" +
+				`<tr><td style="text-align: right">2</td><td>Melon</td><td>a<br>b</td></tr>` + "\n" +
+				"</tbody>\n</table>\n",
+		},
+		{
+			name:  "needNULL",
+			opts:  &WriteOpts{OutNeedNULL: true, OutNULL: "NULL"},
+			types: []string{"text", "text", "text"},
+			want: "<table>\n<thead>\n" +
+				`<tr><th>id</th><th>name</th><th>note</th></tr>` + "\n" +
+				"</thead>\n<tbody>\n" +
+				`<tr><td>1</td><td>Apple &amp; &lt;Pie&gt;</td><td class="null" style="color: gray; font-style: italic">NULL</td></tr>` + "\n" +
+				`<tr><td>2</td><td>Melon</td><td>a<br>b</td></tr>` + "\n" +
+				"</tbody>\n</table>\n",
+		},
+		{
+			name:  "guess",
+			opts:  &WriteOpts{},
+			types: []string{"", "", ""},
+			want: "<table>\n<thead>\n" +
+				`<tr><th>id</th><th>name</th><th>note</th></tr>` + "\n" +
+				"</thead>\n<tbody>\n" +
+				`<tr><td style="text-align: right">1</td><td>Apple &amp; &lt;Pie&gt;</td><td class="null" style="color: gray; font-style: italic"></td></tr>` + "\n" +
+				`<tr><td style="text-align: right">2</td><td>Melon</td><td>a<br>b</td></tr>` + "\n" +
+				"</tbody>\n</table>\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			tt.opts.OutStream = buf
+			w := NewHTMLWriter(tt.opts)
+			names := []string{"id", "name", "note"}
+			if err := w.PreWrite(names, tt.types); err != nil {
+				t.Fatal(err)
+			}
+			rows := [][]any{
+				{1, "Apple & <Pie>", nil},
+				{2, "Melon", "a\nb"},
+			}
+			for _, row := range rows {
+				if err := w.WriteRow(row, names); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := w.PostWrite(); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("HTMLWriter = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestHTMLWriterDocument(t *testing.T) {
+	buf := new(bytes.Buffer)
+	w := NewHTMLWriter(&WriteOpts{OutHTMLDocument: true, OutStream: buf})
+	for _, column := range []string{"a", "b"} {
+		if err := w.PreWrite([]string{column}, []string{"text"}); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.PostWrite(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Finish(); err != nil {
+		t.Fatal(err)
+	}
+	got := buf.String()
+	if !strings.HasPrefix(got, "<!DOCTYPE html>\n") || !strings.HasSuffix(got, "</table>\n</body>\n</html>\n") {
+		t.Errorf("HTMLWriter document = %q", got)
+	}
+	for _, s := range []string{"<!DOCTYPE html>", "</html>", "<table>"} {
+		n := 1
+		if s == "<table>" {
+			n = 2
+		}
+		if c := strings.Count(got, s); c != n {
+			t.Errorf("HTMLWriter document has %d %q, want %d", c, s, n)
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package trdsql
 
 import (
+	"bufio"
 	"encoding/hex"
 	"encoding/json"
 	"unicode/utf8"
@@ -9,37 +10,49 @@ import (
 )
 
 // JSONWriter writes result rows as a JSON array of objects.
+// Each row is written as it arrives, so the whole array is not buffered.
 type JSONWriter struct {
-	writer   *json.Encoder
+	writer   *bufio.Writer
 	outNULL  string
-	results  []*orderedmap.OrderedMap
+	count    int
 	needNULL bool
 }
 
 // NewJSONWriter returns a JSONWriter configured with output options.
 func NewJSONWriter(writeOpts *WriteOpts) *JSONWriter {
 	w := &JSONWriter{}
-	w.writer = json.NewEncoder(writeOpts.OutStream)
-	w.writer.SetIndent("", "  ")
+	w.writer = bufio.NewWriter(writeOpts.OutStream)
 	w.needNULL = writeOpts.OutNeedNULL
 	w.outNULL = writeOpts.OutNULL
 	return w
 }
 
-// PreWrite is area preparation.
+// PreWrite writes the opening bracket of the array.
 func (w *JSONWriter) PreWrite(columns []string, types []string) error {
-	w.results = make([]*orderedmap.OrderedMap, 0)
-	return nil
+	w.count = 0
+	return w.writer.WriteByte('[')
 }
 
-// WriteRow is Addition to array.
+// WriteRow writes the row as an object of the array.
 func (w *JSONWriter) WriteRow(values []any, columns []string) error {
 	m := orderedmap.New()
 	for i, col := range values {
 		m.Set(columns[i], compatibleJSON(col, w.needNULL, w.outNULL))
 	}
-	w.results = append(w.results, m)
-	return nil
+	b, err := json.MarshalIndent(m, "  ", "  ")
+	if err != nil {
+		return err
+	}
+	sep := ",\n  "
+	if w.count == 0 {
+		sep = "\n  "
+	}
+	w.count++
+	if _, err := w.writer.WriteString(sep); err != nil {
+		return err
+	}
+	_, err = w.writer.Write(b)
+	return err
 }
 
 // CompatibleJSON converts the value to a JSON-compatible value.
@@ -82,7 +95,14 @@ func isJSON(s []byte) bool {
 	return err == nil
 }
 
-// PostWrite is actual output.
+// PostWrite writes the closing bracket of the array and flushes.
 func (w *JSONWriter) PostWrite() error {
-	return w.writer.Encode(w.results)
+	end := "\n]\n"
+	if w.count == 0 {
+		end = "]\n"
+	}
+	if _, err := w.writer.WriteString(end); err != nil {
+		return err
+	}
+	return w.writer.Flush()
 }
@@ -1,6 +1,7 @@
 package trdsql
 
 import (
+	"bytes"
 	"reflect"
 	"testing"
 )
@@ -40,3 +41,62 @@ func Test_compatibleJSON(t *testing.T) {
 		})
 	}
 }
+
+func TestJSONWriter(t *testing.T) {
+	tests := []struct {
+		name string
+		rows [][]any
+		want string
+	}{
+		{
+			name: "rows",
+			rows: [][]any{
+				{1, "Orange", nil},
+				{2, "<Melon>", `{"a":[1,2]}`},
+			},
+			want: "[\n" +
+				"  {\n" +
+				"    \"id\": 1,\n" +
+				"    \"name\": \"Orange\",\n" +
+				"    \"note\": null\n" +
+				"  },\n" +
+				"  {\n" +
+				"    \"id\": 2,\n" +
+				"    \"name\": \"\\u003cMelon\\u003e\",\n" +
+				"    \"note\": {\n" +
+				"      \"a\": [\n" +
+				"        1,\n" +
+				"        2\n" +
+				"      ]\n" +
+				"    }\n" +
+				"  }\n" +
+				"]\n",
+		},
+		{
+			name: "empty",
+			rows: nil,
+			want: "[]\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			w := NewJSONWriter(&WriteOpts{OutStream: buf})
+			names := []string{"id", "name", "note"}
+			if err := w.PreWrite(names, []string{"int", "text", "text"}); err != nil {
+				t.Fatal(err)
+			}
+			for _, row := range tt.rows {
+				if err := w.WriteRow(row, names); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := w.PostWrite(); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("JSONWriter = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,100 @@
+package trdsql
+
+import (
+	"bufio"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// LogfmtWriter writes rows as logfmt (space-separated key=value pairs).
+// Values that contain spaces, '=', '"' or control characters are quoted.
+type LogfmtWriter struct {
+	writer   *bufio.Writer
+	outNULL  string
+	keys     []string
+	needNULL bool
+}
+
+// NewLogfmtWriter returns a LogfmtWriter configured with output options.
+func NewLogfmtWriter(writeOpts *WriteOpts) *LogfmtWriter {
+	w := &LogfmtWriter{}
+	w.writer = bufio.NewWriter(writeOpts.OutStream)
+	w.needNULL = writeOpts.OutNeedNULL
+	w.outNULL = writeOpts.OutNULL
+	return w
+}
+
+// PreWrite is area preparation.
+// Characters that cannot be used in the key are replaced with '_'.
+func (w *LogfmtWriter) PreWrite(columns []string, types []string) error {
+	w.keys = make([]string, len(columns))
+	for i, column := range columns {
+		w.keys[i] = logfmtKey(column)
+	}
+	return nil
+}
+
+// WriteRow is row write to logfmt.
+// NULL is written as an empty value (key=).
+func (w *LogfmtWriter) WriteRow(values []any, labels []string) error {
+	for n, col := range values {
+		if n > 0 {
+			if err := w.writer.WriteByte(' '); err != nil {
+				return err
+			}
+		}
+		key := labels[n]
+		if n < len(w.keys) {
+			key = w.keys[n]
+		}
+		if _, err := w.writer.WriteString(key); err != nil {
+			return err
+		}
+		if err := w.writer.WriteByte('='); err != nil {
+			return err
+		}
+		if col == nil && !w.needNULL {
+			continue
+		}
+		str := ValString(col)
+		if col == nil {
+			str = w.outNULL
+		}
+		if _, err := w.writer.WriteString(logfmtQuote(str)); err != nil {
+			return err
+		}
+	}
+	return w.writer.WriteByte('\n')
+}
+
+// PostWrite is flush.
+func (w *LogfmtWriter) PostWrite() error {
+	return w.writer.Flush()
+}
+
+// logfmtKey returns the key in which spaces, '=', '"' and control characters are replaced with '_'.
+func logfmtKey(key string) string {
+	if key == "" {
+		return "_"
+	}
+	return strings.Map(func(r rune) rune {
+		if r <= ' ' || r == '=' || r == '"' || unicode.IsControl(r) {
+			return '_'
+		}
+		return r
+	}, key)
+}
+
+// logfmtQuote quotes the value if necessary.
+func logfmtQuote(value string) string {
+	if value == "" {
+		return `""`
+	}
+	if strings.IndexFunc(value, func(r rune) bool {
+		return r <= ' ' || r == '=' || r == '"' || r == '\\' || unicode.IsControl(r)
+	}) < 0 {
+		return value
+	}
+	return strconv.Quote(value)
+}
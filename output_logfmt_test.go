@@ -0,0 +1,78 @@
+package trdsql
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+)
+
+func TestLogfmtWriter(t *testing.T) {
+	tests := []struct {
+		name string
+		opts *WriteOpts
+		want string
+	}{
+		{
+			name: "default",
+			opts: &WriteOpts{},
+			want: `id=1 first_name="Apple \"Pie\"" price= note=""` + "\n" +
+				`id=2 first_name=Melon price=500 note="a=b\nc"` + "\n",
+		},
+		{
+			name: "needNULL",
+			opts: &WriteOpts{OutNeedNULL: true, OutNULL: "NULL"},
+			want: `id=1 first_name="Apple \"Pie\"" price=NULL note=""` + "\n" +
+				`id=2 first_name=Melon price=500 note="a=b\nc"` + "\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			tt.opts.OutStream = buf
+			w := NewLogfmtWriter(tt.opts)
+			names := []string{"id", "first name", "price", "note"}
+			if err := w.PreWrite(names, []string{"int", "text", "int", "text"}); err != nil {
+				t.Fatal(err)
+			}
+			rows := [][]any{
+				{1, `Apple "Pie"`, nil, ""},
+				{2, "Melon", 500, "a=b\nc"},
+			}
+			for _, row := range rows {
+				if err := w.WriteRow(row, names); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := w.PostWrite(); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("LogfmtWriter = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLogfmtRoundTrip(t *testing.T) {
+	buf := new(bytes.Buffer)
+	w := NewLogfmtWriter(&WriteOpts{OutStream: buf})
+	names := []string{"id", "price", "note"}
+	if err := w.PreWrite(names, []string{"int", "int", "text"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WriteRow([]any{1, nil, ""}, names); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.PostWrite(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewLogfmtReader(buf, NewReadOpts())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := [][]any{{"1", nil, ""}}
+	if got := r.PreReadRow(); !reflect.DeepEqual(got, want) {
+		t.Errorf("LogfmtReader rows = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,135 @@
+package trdsql
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/apache/arrow-go/v18/parquet"
+	"github.com/apache/arrow-go/v18/parquet/compress"
+	"github.com/apache/arrow-go/v18/parquet/pqarrow"
+)
+
+// parquetRowGroupSize is the number of rows in a row group.
+const parquetRowGroupSize = 65536
+
+// ParquetWriter writes rows as an Apache Parquet file.
+// The schema is derived from the column types of the query result,
+// and rows are written out for each row group.
+type ParquetWriter struct {
+	writer  *bufio.Writer
+	batch   *arrowBatch
+	file    *pqarrow.FileWriter
+	props   *parquet.WriterProperties
+	err     error
+	written bool
+}
+
+// NewParquetWriter returns a ParquetWriter configured with output options.
+func NewParquetWriter(writeOpts *WriteOpts) *ParquetWriter {
+	w := &ParquetWriter{}
+	w.writer = bufio.NewWriter(writeOpts.OutStream)
+	codec, err := parquetCodec(writeOpts.OutCodec)
+	if err != nil {
+		w.err = err
+	}
+	w.props = parquet.NewWriterProperties(
+		parquet.WithCompression(codec),
+		parquet.WithMaxRowGroupLength(parquetRowGroupSize),
+	)
+	return w
+}
+
+// parquetCodec returns the compression codec of Parquet.
+// The default is snappy.
+func parquetCodec(name string) (compress.Compression, error) {
+	switch strings.ToLower(name) {
+	case "", "snappy":
+		return compress.Codecs.Snappy, nil
+	case "gz", "gzip":
+		return compress.Codecs.Gzip, nil
+	case "zst", "zstd":
+		return compress.Codecs.Zstd, nil
+	case "lz4":
+		return compress.Codecs.Lz4Raw, nil
+	case "brotli":
+		return compress.Codecs.Brotli, nil
+	case "none", "uncompressed":
+		return compress.Codecs.Uncompressed, nil
+	default:
+		return compress.Codecs.Uncompressed, fmt.Errorf("%w: %s", ErrUnknownCodec, name)
+	}
+}
+
+// PreWrite is preparation.
+// The file is created when the first row is written,
+// because the type of a column whose type name is unknown is
+// inferred from the value.
+func (w *ParquetWriter) PreWrite(columns []string, types []string) error {
+	if w.err != nil {
+		return w.err
+	}
+	if w.written {
+		return fmt.Errorf("parquet: %w", ErrMultipleResults)
+	}
+	w.written = true
+	w.batch = newArrowBatch(columns, types, parquetRowGroupSize)
+	return nil
+}
+
+// WriteRow is row write.
+// When the number of rows reaches the row group size, it is written to the file.
+func (w *ParquetWriter) WriteRow(values []any, columns []string) error {
+	if w.file == nil {
+		if err := w.open(values); err != nil {
+			return err
+		}
+	}
+	if err := w.batch.append(values); err != nil {
+		return err
+	}
+	if w.batch.full() {
+		return w.flush()
+	}
+	return nil
+}
+
+func (w *ParquetWriter) open(values []any) error {
+	schema := w.batch.init(values)
+	// Hide Close of OutStream, because closing the parquet file closes the writer.
+	sink := struct{ io.Writer }{w.writer}
+	file, err := pqarrow.NewFileWriter(schema, sink, w.props, pqarrow.DefaultWriterProps())
+	if err != nil {
+		return fmt.Errorf("parquet: %w", err)
+	}
+	w.file = file
+	return nil
+}
+
+// flush writes the appended rows as a row group.
+func (w *ParquetWriter) flush() error {
+	rec := w.batch.newRecord()
+	if rec == nil {
+		return nil
+	}
+	defer rec.Release()
+	return w.file.Write(rec)
+}
+
+// PostWrite writes the remaining rows and the footer.
+func (w *ParquetWriter) PostWrite() error {
+	if w.file == nil {
+		if err := w.open(nil); err != nil {
+			return err
+		}
+	}
+	defer w.batch.release()
+	if err := w.flush(); err != nil {
+		return err
+	}
+	if err := w.file.Close(); err != nil {
+		return err
+	}
+	return w.writer.Flush()
+}
@@ -0,0 +1,81 @@
+package trdsql
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParquetWriter(t *testing.T) {
+	buf := new(bytes.Buffer)
+	w := NewParquetWriter(&WriteOpts{OutStream: buf, OutCodec: "zstd"})
+	columns := []string{"id", "name", "price", "day", "cnt"}
+	types := []string{"INTEGER", "TEXT", "REAL", "DATE", ""}
+	if err := w.PreWrite(columns, types); err != nil {
+		t.Fatal(err)
+	}
+	day := time.Date(2022, 1, 8, 0, 0, 0, 0, time.UTC)
+	rows := [][]any{
+		{int64(1), "Orange", 50.5, day, int64(3)},
+		{int64(2), []byte("Melon"), nil, "2022-01-09", int64(3)},
+	}
+	for _, row := range rows {
+		if err := w.WriteRow(row, columns); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.PostWrite(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewParquetReader(bytes.NewReader(buf.Bytes()), NewReadOpts(InPreRead(2)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(r.names, columns) {
+		t.Errorf("ParquetWriter names = %v, want %v", r.names, columns)
+	}
+	wantTypes := []string{"bigint", "text", "double precision", "date", "bigint"}
+	if !reflect.DeepEqual(r.types, wantTypes) {
+		t.Errorf("ParquetWriter types = %v, want %v", r.types, wantTypes)
+	}
+	want := [][]any{
+		{int64(1), "Orange", 50.5, "2022-01-08", int64(3)},
+		{int64(2), "Melon", nil, "2022-01-09", int64(3)},
+	}
+	if got := r.PreReadRow(); !reflect.DeepEqual(got, want) {
+		t.Errorf("ParquetWriter rows = %v, want %v", got, want)
+	}
+}
+
+func TestParquetWriter_Errors(t *testing.T) {
+	t.Run("unknownCodec", func(t *testing.T) {
+		w := NewParquetWriter(&WriteOpts{OutStream: new(bytes.Buffer), OutCodec: "unknown"})
+		if err := w.PreWrite([]string{"c1"}, []string{"text"}); !errors.Is(err, ErrUnknownCodec) {
+			t.Errorf("ParquetWriter.PreWrite() error = %v, want %v", err, ErrUnknownCodec)
+		}
+	})
+	t.Run("multipleResults", func(t *testing.T) {
+		w := NewParquetWriter(&WriteOpts{OutStream: new(bytes.Buffer)})
+		if err := w.PreWrite([]string{"c1"}, []string{"text"}); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.PostWrite(); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.PreWrite([]string{"c1"}, []string{"text"}); !errors.Is(err, ErrMultipleResults) {
+			t.Errorf("ParquetWriter.PreWrite() error = %v, want %v", err, ErrMultipleResults)
+		}
+	})
+	t.Run("unableConvert", func(t *testing.T) {
+		w := NewParquetWriter(&WriteOpts{OutStream: new(bytes.Buffer)})
+		if err := w.PreWrite([]string{"c1"}, []string{"int"}); err != nil {
+			t.Fatal(err)
+		}
+		if err := w.WriteRow([]any{"abc"}, []string{"c1"}); !errors.Is(err, ErrUnableConvert) {
+			t.Errorf("ParquetWriter.WriteRow() error = %v, want %v", err, ErrUnableConvert)
+		}
+	})
+}
@@ -0,0 +1,369 @@
+package trdsql
+
+import (
+	"bufio"
+	"encoding/hex"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// SQLWriter writes rows as INSERT statements of the target table.
+// The identifiers are quoted and the literals are escaped for the dialect
+// (sqlite, mysql or postgres), and OutSQLBatch rows are written in one statement.
+//
+// With OutSQLUpsert, the statements are upserts keyed on the columns
+// (ON CONFLICT ... DO UPDATE for sqlite and postgres, ON DUPLICATE KEY UPDATE for mysql).
+// With OutSQLCreate, CREATE TABLE is written first.
+// The column types are converted from the database types,
+// and are guessed from the first row if unknown.
+//
+// NULL is always written as NULL regardless of OutNULL.
+type SQLWriter struct {
+	writer  *bufio.Writer
+	table   string
+	dialect string
+	keySpec string
+	columns []string
+	types   []typeKind
+	keys    []string
+	rows    []string
+	batch   int
+	create  bool
+	created bool
+}
+
+// NewSQLWriter returns a SQLWriter configured with output options.
+func NewSQLWriter(writeOpts *WriteOpts) *SQLWriter {
+	w := &SQLWriter{}
+	w.writer = bufio.NewWriter(writeOpts.OutStream)
+	w.table = writeOpts.OutSQLTable
+	if w.table == "" {
+		w.table = "result"
+	}
+	w.dialect = writeOpts.OutSQLDialect
+	w.create = writeOpts.OutSQLCreate
+	w.batch = max(writeOpts.OutSQLBatch, 1)
+	w.keySpec = writeOpts.OutSQLUpsert
+	return w
+}
+
+// PreWrite checks the options and prepares the column names.
+// CREATE TABLE is written in the first WriteRow (or PostWrite if there are no rows)
+// to guess the unknown types from the values.
+func (w *SQLWriter) PreWrite(columns []string, types []string) error {
+	switch w.dialect {
+	case "", "sqlite", "sqlite3":
+		w.dialect = "sqlite"
+	case "mysql", "postgres":
+	default:
+		return fmt.Errorf("invalid dialect %q [ sqlite | mysql | postgres ]", w.dialect)
+	}
+	w.columns = make([]string, len(columns))
+	w.types = make([]typeKind, len(columns))
+	for i, column := range columns {
+		w.columns[i] = w.quoteIdent(column)
+		if i < len(types) {
+			w.types[i] = dbTypeKind(types[i])
+		}
+	}
+	w.keys = nil
+	if strings.TrimSpace(w.keySpec) != "" {
+		for _, key := range strings.Split(w.keySpec, ",") {
+			key = strings.TrimSpace(key)
+			found := false
+			for _, column := range columns {
+				if column == key {
+					found = true
+					break
+				}
+			}
+			if !found {
+				return fmt.Errorf("upsert key column %q not found", key)
+			}
+			w.keys = append(w.keys, key)
+		}
+	}
+	w.rows = nil
+	w.created = !w.create
+	return nil
+}
+
+// WriteRow is row write to INSERT statements.
+func (w *SQLWriter) WriteRow(values []any, columns []string) error {
+	if !w.created {
+		for i, v := range values {
+			if i < len(w.types) && w.types[i] == typeUnknown {
+				w.types[i] = sqlValueType(v)
+			}
+		}
+		if err := w.writeCreate(); err != nil {
+			return err
+		}
+	}
+	literals := make([]string, len(values))
+	for i, v := range values {
+		literals[i] = w.literal(v)
+	}
+	w.rows = append(w.rows, "("+strings.Join(literals, ", ")+")")
+	if len(w.rows) >= w.batch {
+		return w.writeInsert()
+	}
+	return nil
+}
+
+// PostWrite writes the rest of the rows and flushes.
+func (w *SQLWriter) PostWrite() error {
+	if !w.created {
+		if err := w.writeCreate(); err != nil {
+			return err
+		}
+	}
+	if err := w.writeInsert(); err != nil {
+		return err
+	}
+	return w.writer.Flush()
+}
+
+// writeCreate writes CREATE TABLE.
+// The upsert keys are the primary key.
+func (w *SQLWriter) writeCreate() error {
+	w.created = true
+	defs := make([]string, 0, len(w.columns)+1)
+	for i, column := range w.columns {
+		defs = append(defs, column+" "+w.columnType(w.types[i], w.isKey(i)))
+	}
+	if len(w.keys) > 0 {
+		keys := make([]string, len(w.keys))
+		for i, key := range w.keys {
+			keys[i] = w.quoteIdent(key)
+		}
+		defs = append(defs, "PRIMARY KEY ("+strings.Join(keys, ", ")+")")
+	}
+	_, err := w.writer.WriteString("CREATE TABLE " + w.quoteTable() + " (\n  " + strings.Join(defs, ",\n  ") + "\n);\n")
+	return err
+}
+
+// writeInsert writes the buffered rows as an INSERT statement.
+func (w *SQLWriter) writeInsert() error {
+	if len(w.rows) == 0 {
+		return nil
+	}
+	var b strings.Builder
+	b.WriteString("INSERT INTO " + w.quoteTable() + " (" + strings.Join(w.columns, ", ") + ") VALUES\n")
+	b.WriteString(strings.Join(w.rows, ",\n"))
+	if len(w.keys) > 0 {
+		b.WriteString("\n" + w.upsertClause())
+	}
+	b.WriteString(";\n")
+	w.rows = w.rows[:0]
+	_, err := w.writer.WriteString(b.String())
+	return err
+}
+
+// upsertClause returns the clause that updates the non-key columns on conflict.
+func (w *SQLWriter) upsertClause() string {
+	var sets []string
+	for i, column := range w.columns {
+		if w.isKey(i) {
+			continue
+		}
+		if w.dialect == "mysql" {
+			sets = append(sets, column+" = VALUES("+column+")")
+		} else {
+			sets = append(sets, column+" = excluded."+column)
+		}
+	}
+	if w.dialect == "mysql" {
+		if len(sets) == 0 {
+			key := w.quoteIdent(w.keys[0])
+			sets = append(sets, key+" = "+key)
+		}
+		return "ON DUPLICATE KEY UPDATE " + strings.Join(sets, ", ")
+	}
+	keys := make([]string, len(w.keys))
+	for i, key := range w.keys {
+		keys[i] = w.quoteIdent(key)
+	}
+	clause := "ON CONFLICT (" + strings.Join(keys, ", ") + ") DO "
+	if len(sets) == 0 {
+		return clause + "NOTHING"
+	}
+	return clause + "UPDATE SET " + strings.Join(sets, ", ")
+}
+
+// isKey returns true if the i-th column is an upsert key.
+func (w *SQLWriter) isKey(i int) bool {
+	for _, key := range w.keys {
+		if w.quoteIdent(key) == w.columns[i] {
+			return true
+		}
+	}
+	return false
+}
+
+// quoteTable returns the quoted table name.
+// The schema-qualified name (schema.table) is quoted separately.
+func (w *SQLWriter) quoteTable() string {
+	parts := splitTableName(w.table)
+	for i, part := range parts {
+		parts[i] = w.quoteIdent(part)
+	}
+	return strings.Join(parts, ".")
+}
+
+// splitTableName splits the table name into the parts of the schema-qualified name.
+// A part enclosed in double quotes or backquotes ("my.table") is one identifier
+// containing ".", and the doubled quote in it is a quote.
+func splitTableName(name string) []string {
+	var parts []string
+	var part strings.Builder
+	var quote rune
+	start := true
+	runes := []rune(name)
+	for i := 0; i < len(runes); i++ {
+		c := runes[i]
+		switch {
+		case quote != 0 && c == quote:
+			if i+1 < len(runes) && runes[i+1] == quote {
+				part.WriteRune(c)
+				i++
+				continue
+			}
+			quote = 0
+		case quote != 0:
+			part.WriteRune(c)
+		case start && (c == '"' || c == '`'):
+			quote = c
+		case c == '.':
+			parts = append(parts, part.String())
+			part.Reset()
+			start = true
+			continue
+		default:
+			part.WriteRune(c)
+		}
+		start = false
+	}
+	return append(parts, part.String())
+}
+
+// quoteIdent returns the identifier quoted for the dialect.
+func (w *SQLWriter) quoteIdent(name string) string {
+	if w.dialect == "mysql" {
+		return "`" + strings.ReplaceAll(name, "`", "``") + "`"
+	}
+	return `"` + strings.ReplaceAll(name, `"`, `""`) + `"`
+}
+
+// columnType returns the column type of the dialect from the kind of the type.
+// The decimal columns of mysql are DECIMAL(65,30), because DECIMAL without
+// the precision and scale is DECIMAL(10,0) and drops the fraction.
+// The text key columns of mysql are VARCHAR because TEXT cannot be a primary key.
+func (w *SQLWriter) columnType(kind typeKind, key bool) string {
+	switch kind {
+	case typeInt:
+		if w.dialect == "sqlite" {
+			return "INTEGER"
+		}
+		return "INT"
+	case typeBigint:
+		return "BIGINT"
+	case typeFloat:
+		if w.dialect == "mysql" {
+			return "DOUBLE"
+		}
+		return "NUMERIC"
+	case typeDecimal:
+		if w.dialect == "mysql" {
+			return "DECIMAL(65,30)"
+		}
+		return "NUMERIC"
+	case typeBool:
+		return "BOOLEAN"
+	case typeDate, typeTime, typeTimestamp:
+		if w.dialect == "mysql" {
+			return "DATETIME"
+		}
+		return "TIMESTAMP"
+	default:
+		if w.dialect == "mysql" && key {
+			return "VARCHAR(255)"
+		}
+		return "TEXT"
+	}
+}
+
+// sqlValueType returns the kind of the type of the value.
+func sqlValueType(v any) typeKind {
+	switch v.(type) {
+	case int, int8, int16, int32, uint8, uint16:
+		return typeInt
+	case int64, uint, uint32, uint64:
+		return typeBigint
+	case float32, float64:
+		return typeFloat
+	case bool:
+		return typeBool
+	case time.Time:
+		return typeTimestamp
+	default:
+		return typeText
+	}
+}
+
+// literal returns the SQL literal of the value for the dialect.
+func (w *SQLWriter) literal(v any) string {
+	switch t := v.(type) {
+	case nil:
+		return "NULL"
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return fmt.Sprint(t)
+	case float32:
+		return w.floatLiteral(float64(t), 32)
+	case float64:
+		return w.floatLiteral(t, 64)
+	case bool:
+		if t {
+			return "TRUE"
+		}
+		return "FALSE"
+	case time.Time:
+		if w.dialect == "mysql" {
+			return w.quoteString(t.Format("2006-01-02 15:04:05.999999"))
+		}
+		return w.quoteString(t.Format(time.RFC3339Nano))
+	case []byte:
+		if utf8.Valid(t) {
+			return w.quoteString(string(t))
+		}
+		if w.dialect == "postgres" {
+			return `'\x` + hex.EncodeToString(t) + `'`
+		}
+		return "X'" + hex.EncodeToString(t) + "'"
+	default:
+		return w.quoteString(ValString(v))
+	}
+}
+
+// floatLiteral returns the literal of the float.
+// NaN and Inf are strings because they are not numeric literals.
+func (w *SQLWriter) floatLiteral(f float64, bitSize int) string {
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return w.quoteString(strconv.FormatFloat(f, 'g', -1, bitSize))
+	}
+	return strconv.FormatFloat(f, 'g', -1, bitSize)
+}
+
+// quoteString returns the string literal escaped for the dialect.
+// mysql also escapes backslashes and NUL, because they are escape characters by default.
+func (w *SQLWriter) quoteString(s string) string {
+	s = strings.ReplaceAll(s, "'", "''")
+	if w.dialect == "mysql" {
+		s = strings.NewReplacer(`\`, `\\`, "\x00", `\0`).Replace(s)
+	}
+	return "'" + s + "'"
+}
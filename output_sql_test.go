@@ -0,0 +1,212 @@
+package trdsql
+
+import (
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestSQLWriter(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    *WriteOpts
+		types   []string
+		want    string
+		wantErr bool
+	}{
+		{
+			name:  "sqlite",
+			opts:  &WriteOpts{OutSQLTable: "fruit", OutSQLBatch: 100},
+			types: []string{"int", "text", "text"},
+			want: `INSERT INTO "fruit" ("id", "name", "note") VALUES` + "\n" +
+				`(1, 'Apple''s', NULL),` + "\n" +
+				`(2, 'Melon', 'a\b')` + ";\n",
+		},
+		{
+			name:  "batch",
+			opts:  &WriteOpts{OutSQLTable: "fruit", OutSQLBatch: 1},
+			types: []string{"int", "text", "text"},
+			want: `INSERT INTO "fruit" ("id", "name", "note") VALUES` + "\n" +
+				`(1, 'Apple''s', NULL)` + ";\n" +
+				`INSERT INTO "fruit" ("id", "name", "note") VALUES` + "\n" +
+				`(2, 'Melon', 'a\b')` + ";\n",
+		},
+		{
+			name:  "mysql",
+			opts:  &WriteOpts{OutSQLTable: "my`fruit", OutSQLDialect: "mysql", OutSQLBatch: 100},
+			types: []string{"int", "text", "text"},
+			want: "INSERT INTO `my``fruit` (`id`, `name`, `note`) VALUES\n" +
+				`(1, 'Apple''s', NULL),` + "\n" +
+				`(2, 'Melon', 'a\\b')` + ";\n",
+		},
+		{
+			name:  "postgresUpsert",
+			opts:  &WriteOpts{OutSQLTable: "public.fruit", OutSQLDialect: "postgres", OutSQLBatch: 100, OutSQLUpsert: "id"},
+			types: []string{"int", "text", "text"},
+			want: `INSERT INTO "public"."fruit" ("id", "name", "note") VALUES` + "\n" +
+				`(1, 'Apple''s', NULL),` + "\n" +
+				`(2, 'Melon', 'a\b')` + "\n" +
+				`ON CONFLICT ("id") DO UPDATE SET "name" = excluded."name", "note" = excluded."note"` + ";\n",
+		},
+		{
+			name:  "mysqlUpsert",
+			opts:  &WriteOpts{OutSQLTable: "fruit", OutSQLDialect: "mysql", OutSQLBatch: 100, OutSQLUpsert: "id, name"},
+			types: []string{"int", "text", "text"},
+			want: "INSERT INTO `fruit` (`id`, `name`, `note`) VALUES\n" +
+				`(1, 'Apple''s', NULL),` + "\n" +
+				`(2, 'Melon', 'a\\b')` + "\n" +
+				"ON DUPLICATE KEY UPDATE `note` = VALUES(`note`);\n",
+		},
+		{
+			name:  "create",
+			opts:  &WriteOpts{OutSQLTable: "fruit", OutSQLDialect: "mysql", OutSQLBatch: 100, OutSQLCreate: true, OutSQLUpsert: "name"},
+			types: []string{"int", "varchar", ""},
+			want: "CREATE TABLE `fruit` (\n" +
+				"  `id` INT,\n" +
+				"  `name` VARCHAR(255),\n" +
+				"  `note` TEXT,\n" +
+				"  PRIMARY KEY (`name`)\n" +
+				");\n" +
+				"INSERT INTO `fruit` (`id`, `name`, `note`) VALUES\n" +
+				`(1, 'Apple''s', NULL),` + "\n" +
+				`(2, 'Melon', 'a\\b')` + "\n" +
+				"ON DUPLICATE KEY UPDATE `id` = VALUES(`id`), `note` = VALUES(`note`);\n",
+		},
+		{
+			name:    "invalidDialect",
+			opts:    &WriteOpts{OutSQLDialect: "oracle"},
+			types:   []string{"int", "text", "text"},
+			wantErr: true,
+		},
+		{
+			name:    "invalidKey",
+			opts:    &WriteOpts{OutSQLUpsert: "price"},
+			types:   []string{"int", "text", "text"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			tt.opts.OutStream = buf
+			w := NewSQLWriter(tt.opts)
+			names := []string{"id", "name", "note"}
+			err := w.PreWrite(names, tt.types)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("SQLWriter.PreWrite() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			rows := [][]any{
+				{1, "Apple's", nil},
+				{2, "Melon", `a\b`},
+			}
+			for _, row := range rows {
+				if err := w.WriteRow(row, names); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := w.PostWrite(); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("SQLWriter = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSQLWriter_literal(t *testing.T) {
+	ts := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+	tests := []struct {
+		name    string
+		dialect string
+		v       any
+		want    string
+	}{
+		{name: "float", dialect: "sqlite", v: 1.5, want: "1.5"},
+		{name: "bool", dialect: "postgres", v: true, want: "TRUE"},
+		{name: "time", dialect: "postgres", v: ts, want: "'2024-01-02T03:04:05Z'"},
+		{name: "mysqlTime", dialect: "mysql", v: ts, want: "'2024-01-02 03:04:05'"},
+		{name: "blob", dialect: "sqlite", v: []byte{0xff, 0x00}, want: "X'ff00'"},
+		{name: "bytea", dialect: "postgres", v: []byte{0xff, 0x00}, want: `'\xff00'`},
+		{name: "bytesText", dialect: "sqlite", v: []byte("it's"), want: "'it''s'"},
+		{name: "mysqlNUL", dialect: "mysql", v: "a\x00b", want: `'a\0b'`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &SQLWriter{dialect: tt.dialect}
+			if got := w.literal(tt.v); got != tt.want {
+				t.Errorf("SQLWriter.literal() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSQLWriter_create(t *testing.T) {
+	buf := new(bytes.Buffer)
+	w := NewSQLWriter(&WriteOpts{OutSQLCreate: true, OutStream: buf})
+	if err := w.PreWrite([]string{"id", "price", "name"}, []string{"", "", ""}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WriteRow([]any{int64(1), 1.5, nil}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.PostWrite(); err != nil {
+		t.Fatal(err)
+	}
+	want := `CREATE TABLE "result" (` + "\n" +
+		`  "id" BIGINT,` + "\n" +
+		`  "price" NUMERIC,` + "\n" +
+		`  "name" TEXT` + "\n" +
+		");\n" +
+		`INSERT INTO "result" ("id", "price", "name") VALUES` + "\n" +
+		"(1, 1.5, NULL);\n"
+	if got := buf.String(); got != want {
+		t.Errorf("SQLWriter = %q, want %q", got, want)
+	}
+}
+
+func TestSQLWriter_columnTypes(t *testing.T) {
+	buf := new(bytes.Buffer)
+	w := NewSQLWriter(&WriteOpts{OutSQLCreate: true, OutSQLDialect: "mysql", OutStream: buf})
+	if err := w.PreWrite([]string{"price", "rate"}, []string{"DECIMAL", "DOUBLE"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.PostWrite(); err != nil {
+		t.Fatal(err)
+	}
+	want := "CREATE TABLE `result` (\n" +
+		"  `price` DECIMAL(65,30),\n" +
+		"  `rate` DOUBLE\n" +
+		");\n"
+	if got := buf.String(); got != want {
+		t.Errorf("SQLWriter = %q, want %q", got, want)
+	}
+}
+
+func TestSQLWriter_quoteTable(t *testing.T) {
+	tests := []struct {
+		name    string
+		dialect string
+		table   string
+		want    string
+	}{
+		{name: "table", dialect: "sqlite", table: "fruit", want: `"fruit"`},
+		{name: "schema", dialect: "postgres", table: "public.fruit", want: `"public"."fruit"`},
+		{name: "quotedDot", dialect: "postgres", table: `"my.fruit"`, want: `"my.fruit"`},
+		{name: "quotedSchema", dialect: "postgres", table: `public."my.fruit"`, want: `"public"."my.fruit"`},
+		{name: "doubledQuote", dialect: "sqlite", table: `"a""b.c"`, want: `"a""b.c"`},
+		{name: "backquote", dialect: "mysql", table: "`db`.`my.fruit`", want: "`db`.`my.fruit`"},
+		{name: "quoteInside", dialect: "mysql", table: "my`fruit", want: "`my``fruit`"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := &SQLWriter{dialect: tt.dialect, table: tt.table}
+			if got := w.quoteTable(); got != tt.want {
+				t.Errorf("SQLWriter.quoteTable() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -1,12 +1,22 @@
 package trdsql
 
 import (
+	"bufio"
+	"regexp"
 	"strings"
 
+	runewidth "github.com/mattn/go-runewidth"
 	"github.com/olekukonko/tablewriter"
 )
 
 // TWWriter renders rows as an ASCII/Markdown table.
+//
+// By default, all rows are buffered to compute the column widths.
+// With OutStreamRows or OutMaxWidth, the table is streamed:
+// the column widths are computed from the first OutStreamRows rows
+// (limited to OutMaxWidth, or OutMaxWidth if OutStreamRows is 0),
+// and the rows are written as they arrive.
+// The cells that exceed the width are truncated with an ellipsis.
 type TWWriter struct {
 	writeOpts *WriteOpts
 	writer    *tablewriter.Table
@@ -14,8 +24,20 @@ type TWWriter struct {
 	results   []string
 	needNULL  bool
 	markdown  bool
+
+	stream    *bufio.Writer
+	header    []string
+	widths    []int
+	pending   [][]string
+	rows      int
+	maxWidth  int
+	streaming bool
+	started   bool
 }
 
+// twNumber is the pattern of the number that is right-aligned, the same as tablewriter.
+var twNumber = regexp.MustCompile(`^-?(?:\d{1,3}(?:,\d{3})*|\d+)(?:\.\d+)?$`)
+
 // NewTWWriter returns a TWWriter configured with output options.
 func NewTWWriter(writeOpts *WriteOpts, markdown bool) *TWWriter {
 	w := &TWWriter{}
@@ -23,11 +45,26 @@ func NewTWWriter(writeOpts *WriteOpts, markdown bool) *TWWriter {
 	w.needNULL = writeOpts.OutNeedNULL
 	w.outNULL = writeOpts.OutNULL
 	w.markdown = markdown
+	w.rows = max(writeOpts.OutStreamRows, 0)
+	w.maxWidth = max(writeOpts.OutMaxWidth, 0)
+	w.streaming = w.rows > 0 || w.maxWidth > 0
 	return w
 }
 
 // PreWrite is preparation.
 func (w *TWWriter) PreWrite(columns []string, types []string) error {
+	w.results = make([]string, len(columns))
+	if w.streaming {
+		w.stream = bufio.NewWriter(w.writeOpts.OutStream)
+		w.header = columns
+		w.pending = nil
+		w.started = false
+		if w.rows == 0 {
+			return w.start()
+		}
+		return nil
+	}
+
 	w.writer = tablewriter.NewWriter(w.writeOpts.OutStream)
 	w.writer.SetAutoFormatHeaders(false)
 	w.writer.SetAutoWrapText(!w.writeOpts.OutNoWrap)
@@ -36,8 +73,6 @@ func (w *TWWriter) PreWrite(columns []string, types []string) error {
 		w.writer.SetCenterSeparator("|")
 	}
 	w.writer.SetHeader(columns)
-	w.results = make([]string, len(columns))
-
 	return nil
 }
 
@@ -53,12 +88,140 @@ func (w *TWWriter) WriteRow(values []any, columns []string) error {
 		}
 		w.results[i] = str
 	}
-	w.writer.Append(w.results)
-	return nil
+	if !w.streaming {
+		w.writer.Append(w.results)
+		return nil
+	}
+
+	if w.started {
+		return w.writeLine(w.results, false)
+	}
+	w.pending = append(w.pending, append([]string{}, w.results...))
+	if len(w.pending) < w.rows {
+		return nil
+	}
+	return w.start()
 }
 
 // PostWrite is actual output.
 func (w *TWWriter) PostWrite() error {
-	w.writer.Render()
+	if !w.streaming {
+		w.writer.Render()
+		return nil
+	}
+
+	if !w.started {
+		if err := w.start(); err != nil {
+			return err
+		}
+	}
+	if !w.markdown {
+		if err := w.writeBorder("+"); err != nil {
+			return err
+		}
+	}
+	return w.stream.Flush()
+}
+
+// start computes the column widths from the pending rows,
+// and writes the header and the pending rows.
+func (w *TWWriter) start() error {
+	w.started = true
+	w.widths = make([]int, len(w.header))
+	for i := range w.widths {
+		if w.rows == 0 {
+			w.widths[i] = w.maxWidth
+			continue
+		}
+		for _, record := range append([][]string{w.header}, w.pending...) {
+			if i >= len(record) {
+				continue
+			}
+			for _, line := range strings.Split(record[i], "\n") {
+				w.widths[i] = max(w.widths[i], tablewriter.DisplayWidth(line))
+			}
+		}
+		if w.maxWidth > 0 {
+			w.widths[i] = min(w.widths[i], w.maxWidth)
+		}
+	}
+
+	if !w.markdown {
+		if err := w.writeBorder("+"); err != nil {
+			return err
+		}
+	}
+	if err := w.writeLine(w.header, true); err != nil {
+		return err
+	}
+	sep := "+"
+	if w.markdown {
+		sep = "|"
+	}
+	if err := w.writeBorder(sep); err != nil {
+		return err
+	}
+	for _, record := range w.pending {
+		if err := w.writeLine(record, false); err != nil {
+			return err
+		}
+	}
+	w.pending = nil
 	return nil
 }
+
+// writeBorder writes the border line with the separator.
+func (w *TWWriter) writeBorder(sep string) error {
+	var b strings.Builder
+	b.WriteString(sep)
+	for _, width := range w.widths {
+		b.WriteString(strings.Repeat("-", width+2) + sep)
+	}
+	b.WriteByte('\n')
+	_, err := w.stream.WriteString(b.String())
+	return err
+}
+
+// writeLine writes the record.
+// The header is centered, and the numbers are right-aligned.
+// A record with newlines is written in multiple lines.
+func (w *TWWriter) writeLine(record []string, header bool) error {
+	cells := make([][]string, len(w.widths))
+	height := 1
+	for i := range w.widths {
+		if i < len(record) {
+			cells[i] = strings.Split(record[i], "\n")
+		}
+		height = max(height, len(cells[i]))
+	}
+	var b strings.Builder
+	for n := range height {
+		b.WriteString("|")
+		for i, width := range w.widths {
+			str := ""
+			if n < len(cells[i]) {
+				str = twTruncate(cells[i][n], width)
+			}
+			switch {
+			case header:
+				str = tablewriter.Pad(str, " ", width)
+			case i < len(record) && twNumber.MatchString(strings.TrimSpace(record[i])):
+				str = tablewriter.PadLeft(str, " ", width)
+			default:
+				str = tablewriter.PadRight(str, " ", width)
+			}
+			b.WriteString(" " + str + " |")
+		}
+		b.WriteByte('\n')
+	}
+	_, err := w.stream.WriteString(b.String())
+	return err
+}
+
+// twTruncate truncates the string to the display width with an ellipsis.
+func twTruncate(str string, width int) string {
+	if tablewriter.DisplayWidth(str) <= width {
+		return str
+	}
+	return runewidth.Truncate(str, width, "…")
+}
@@ -0,0 +1,103 @@
+package trdsql
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestTWWriterStream(t *testing.T) {
+	tests := []struct {
+		name     string
+		opts     *WriteOpts
+		markdown bool
+		want     string
+	}{
+		{
+			name: "streamRows",
+			opts: &WriteOpts{OutStreamRows: 2},
+			want: "+----+--------+\n" +
+				"| id |  name  |\n" +
+				"+----+--------+\n" +
+				"|  1 | Orange |\n" +
+				"|  2 | メロン |\n" +
+				"| 10 | Apple… |\n" +
+				"+----+--------+\n",
+		},
+		{
+			name:     "markdown",
+			opts:     &WriteOpts{OutStreamRows: 2},
+			markdown: true,
+			want: "| id |  name  |\n" +
+				"|----|--------|\n" +
+				"|  1 | Orange |\n" +
+				"|  2 | メロン |\n" +
+				"| 10 | Apple… |\n",
+		},
+		{
+			name: "maxWidth",
+			opts: &WriteOpts{OutStreamRows: 10, OutMaxWidth: 5},
+			want: "+----+-------+\n" +
+				"| id | name  |\n" +
+				"+----+-------+\n" +
+				"|  1 | Oran… |\n" +
+				"|  2 | メロ… |\n" +
+				"| 10 | Appl… |\n" +
+				"+----+-------+\n",
+		},
+		{
+			name: "fixedWidth",
+			opts: &WriteOpts{OutMaxWidth: 3},
+			want: "+-----+-----+\n" +
+				"| id  | na… |\n" +
+				"+-----+-----+\n" +
+				"|   1 | Or… |\n" +
+				"|   2 | メ… |\n" +
+				"|  10 | Ap… |\n" +
+				"+-----+-----+\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			tt.opts.OutStream = buf
+			w := NewTWWriter(tt.opts, tt.markdown)
+			names := []string{"id", "name"}
+			if err := w.PreWrite(names, []string{"int", "text"}); err != nil {
+				t.Fatal(err)
+			}
+			rows := [][]any{
+				{1, "Orange"},
+				{2, "メロン"},
+				{10, "Apple pie"},
+			}
+			for _, row := range rows {
+				if err := w.WriteRow(row, names); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := w.PostWrite(); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("TWWriter = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTWWriterStreamWritesAsRowsArrive(t *testing.T) {
+	buf := new(bytes.Buffer)
+	w := NewTWWriter(&WriteOpts{OutStreamRows: 1, OutStream: buf}, false)
+	if err := w.PreWrite([]string{"id"}, []string{"int"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WriteRow([]any{1}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if len(w.pending) != 0 || !w.started {
+		t.Errorf("TWWriter pending = %d, started = %v, want 0, true", len(w.pending), w.started)
+	}
+	if err := w.PostWrite(); err != nil {
+		t.Fatal(err)
+	}
+}
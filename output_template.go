@@ -0,0 +1,267 @@
+package trdsql
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// ErrNoTemplate is returned when the template is not specified.
+var ErrNoTemplate = errors.New("no template")
+
+// TemplateWriter writes rows with a Go text/template.
+// The template can define "header", "row" and "footer" templates,
+// and the whole template is the row template if "row" is not defined.
+//
+//	{{define "header"}}# {{join ", " .Columns}}{{"\n"}}{{end}}
+//	{{define "row"}}{{.Index}}: {{.Row.name}}{{"\n"}}{{end}}
+//	{{define "footer"}}{{.Count}} rows{{"\n"}}{{end}}
+//
+// NULL is an empty string (or OutNULL with OutNeedNULL).
+type TemplateWriter struct {
+	writer   *bufio.Writer
+	file     string
+	text     string
+	outNULL  string
+	row      *template.Template
+	header   *template.Template
+	footer   *template.Template
+	data     templateData
+	needNULL bool
+}
+
+// templateData is the data passed to the template.
+type templateData struct {
+	// Row is the row as a map of the column name to the value.
+	Row map[string]any
+	// Columns is the column names.
+	Columns []string
+	// Types is the column types.
+	Types []string
+	// Values is the values of the row in the order of the columns.
+	Values []any
+	// Index is the 1-based row number.
+	Index int
+	// Count is the number of rows written.
+	Count int
+}
+
+// NewTemplateWriter returns a TemplateWriter configured with output options.
+func NewTemplateWriter(writeOpts *WriteOpts) *TemplateWriter {
+	w := &TemplateWriter{}
+	w.writer = bufio.NewWriter(writeOpts.OutStream)
+	w.file = writeOpts.OutTemplate
+	w.text = writeOpts.OutTemplateString
+	w.needNULL = writeOpts.OutNeedNULL
+	w.outNULL = writeOpts.OutNULL
+	return w
+}
+
+// PreWrite parses the template and writes the header.
+func (w *TemplateWriter) PreWrite(columns []string, types []string) error {
+	name, text := "template", w.text
+	if w.file != "" {
+		b, err := os.ReadFile(w.file)
+		if err != nil {
+			return err
+		}
+		name, text = filepath.Base(w.file), string(b)
+	}
+	if text == "" {
+		return ErrNoTemplate
+	}
+	t, err := template.New(name).Funcs(templateFuncs).Parse(text)
+	if err != nil {
+		return err
+	}
+	w.row = t
+	if row := t.Lookup("row"); row != nil {
+		w.row = row
+	}
+	w.header = t.Lookup("header")
+	w.footer = t.Lookup("footer")
+
+	w.data = templateData{Columns: columns, Types: types}
+	if w.header == nil {
+		return nil
+	}
+	return w.header.Execute(w.writer, w.data)
+}
+
+// WriteRow executes the row template.
+func (w *TemplateWriter) WriteRow(values []any, columns []string) error {
+	w.data.Count++
+	w.data.Index = w.data.Count
+	w.data.Row = make(map[string]any, len(values))
+	w.data.Values = make([]any, len(values))
+	for i, col := range values {
+		v := col
+		switch t := col.(type) {
+		case nil:
+			v = ""
+			if w.needNULL {
+				v = w.outNULL
+			}
+		case []byte:
+			v = ValString(t)
+		}
+		w.data.Values[i] = v
+		if i < len(w.data.Columns) {
+			w.data.Row[w.data.Columns[i]] = v
+		}
+	}
+	return w.row.Execute(w.writer, w.data)
+}
+
+// PostWrite writes the footer and flushes.
+func (w *TemplateWriter) PostWrite() error {
+	if w.footer != nil {
+		w.data.Row = nil
+		w.data.Values = nil
+		if err := w.footer.Execute(w.writer, w.data); err != nil {
+			return err
+		}
+	}
+	return w.writer.Flush()
+}
+
+// templateFuncs is the helper functions of the template.
+var templateFuncs = template.FuncMap{
+	"str":     ValString,
+	"upper":   strings.ToUpper,
+	"lower":   strings.ToLower,
+	"trim":    strings.TrimSpace,
+	"replace": func(old, repl string, s any) string { return strings.ReplaceAll(ValString(s), old, repl) },
+	"join":    func(sep string, list []string) string { return strings.Join(list, sep) },
+	"default": templateDefault,
+	"json":    templateJSON,
+	"xml":     templateXML,
+	"csv":     templateCSV,
+	"sql":     templateSQL,
+	"shell":   templateShell,
+	"number":  templateNumber,
+	"date":    templateDate,
+}
+
+// templateDefault returns def if the value is empty.
+func templateDefault(def any, v any) any {
+	if v == nil || ValString(v) == "" {
+		return def
+	}
+	return v
+}
+
+// templateJSON returns the value as JSON.
+func templateJSON(v any) (string, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
+}
+
+// templateXML returns the value escaped for XML.
+func templateXML(v any) (string, error) {
+	var b strings.Builder
+	if err := xml.EscapeText(&b, []byte(ValString(v))); err != nil {
+		return "", err
+	}
+	return b.String(), nil
+}
+
+// templateCSV returns the value quoted for CSV if necessary.
+func templateCSV(v any) string {
+	str := ValString(v)
+	if str == "" || !strings.ContainsAny(str, ",\"\r\n") && str[0] != ' ' && str[len(str)-1] != ' ' {
+		return str
+	}
+	return `"` + strings.ReplaceAll(str, `"`, `""`) + `"`
+}
+
+// templateSQL returns the value as a SQL string literal.
+func templateSQL(v any) string {
+	return "'" + strings.ReplaceAll(ValString(v), "'", "''") + "'"
+}
+
+// templateShell returns the value quoted for the POSIX shell.
+func templateShell(v any) string {
+	return "'" + strings.ReplaceAll(ValString(v), "'", `'\''`) + "'"
+}
+
+// templateNumber returns the number with thousands separators and the decimal places.
+// The value is a number or a string of a number, and an empty string is returned as is.
+//
+//	{{number 2 1234.5}} -> 1,234.50
+func templateNumber(decimals int, v any) (string, error) {
+	var f float64
+	switch t := v.(type) {
+	case int:
+		f = float64(t)
+	case int32:
+		f = float64(t)
+	case int64:
+		f = float64(t)
+	case float32:
+		f = float64(t)
+	case float64:
+		f = t
+	default:
+		str := strings.TrimSpace(ValString(v))
+		if str == "" {
+			return "", nil
+		}
+		var err error
+		f, err = strconv.ParseFloat(str, 64)
+		if err != nil {
+			return "", fmt.Errorf("number: %w", err)
+		}
+	}
+	if math.IsNaN(f) || math.IsInf(f, 0) {
+		return strconv.FormatFloat(f, 'f', -1, 64), nil
+	}
+	str := strconv.FormatFloat(math.Abs(f), 'f', max(decimals, 0), 64)
+	integer, fraction, _ := strings.Cut(str, ".")
+	var b strings.Builder
+	if f < 0 && strings.Trim(str, "0.") != "" {
+		b.WriteByte('-')
+	}
+	for i, c := range integer {
+		if i > 0 && (len(integer)-i)%3 == 0 {
+			b.WriteByte(',')
+		}
+		b.WriteRune(c)
+	}
+	if fraction != "" {
+		b.WriteString("." + fraction)
+	}
+	return b.String(), nil
+}
+
+// templateDate returns the date formatted with the Go layout.
+// The value is a time.Time or a string of a date parsed by parseTime,
+// and an empty string is returned as is.
+//
+//	{{date "2006/01/02" .Row.created}}
+func templateDate(layout string, v any) (string, error) {
+	if t, ok := v.(time.Time); ok {
+		return t.Format(layout), nil
+	}
+	str := strings.TrimSpace(ValString(v))
+	if str == "" {
+		return "", nil
+	}
+	t, err := parseTime(str)
+	if err != nil {
+		return "", fmt.Errorf("date: cannot parse %q", str)
+	}
+	return t.Format(layout), nil
+}
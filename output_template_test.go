@@ -0,0 +1,135 @@
+package trdsql
+
+import (
+	"bytes"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+func TestTemplateWriter(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    *WriteOpts
+		want    string
+		wantErr error
+	}{
+		{
+			name: "row",
+			opts: &WriteOpts{OutTemplateString: `{{.Row.name}}={{.Row.price}}{{"\n"}}`},
+			want: "Apple=1500\nMelon=\n",
+		},
+		{
+			name: "sections",
+			opts: &WriteOpts{OutTemplateString: `{{define "header"}}{{join "|" .Columns}}{{"\n"}}{{end}}` +
+				`{{define "row"}}{{.Index}}:{{index .Values 1}}:{{number 1 .Row.price}}{{"\n"}}{{end}}` +
+				`{{define "footer"}}{{.Count}} rows{{"\n"}}{{end}}`},
+			want: "id|name|price\n1:Apple:1,500.0\n2:Melon:\n2 rows\n",
+		},
+		{
+			name: "needNULL",
+			opts: &WriteOpts{OutTemplateString: `{{.Row.price}} `, OutNeedNULL: true, OutNULL: "NULL"},
+			want: "1500 NULL ",
+		},
+		{
+			name: "default",
+			opts: &WriteOpts{OutTemplateString: `{{default "-" .Row.price}} `},
+			want: "1500 - ",
+		},
+		{
+			name:    "noTemplate",
+			opts:    &WriteOpts{},
+			wantErr: ErrNoTemplate,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			tt.opts.OutStream = buf
+			w := NewTemplateWriter(tt.opts)
+			names := []string{"id", "name", "price"}
+			err := w.PreWrite(names, []string{"int", "text", "int"})
+			if !errors.Is(err, tt.wantErr) {
+				t.Fatalf("TemplateWriter.PreWrite() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil {
+				return
+			}
+			rows := [][]any{
+				{1, "Apple", 1500},
+				{2, "Melon", nil},
+			}
+			for _, row := range rows {
+				if err := w.WriteRow(row, names); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := w.PostWrite(); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("TemplateWriter = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTemplateWriterFile(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "test.tmpl")
+	if err := os.WriteFile(file, []byte(`{{.Row.id}},{{csv .Row.name}}`+"\n"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	buf := new(bytes.Buffer)
+	w := NewTemplateWriter(&WriteOpts{OutTemplate: file, OutStream: buf})
+	if err := w.PreWrite([]string{"id", "name"}, []string{"", ""}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WriteRow([]any{1, "a,b"}, nil); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.PostWrite(); err != nil {
+		t.Fatal(err)
+	}
+	if got, want := buf.String(), "1,\"a,b\"\n"; got != want {
+		t.Errorf("TemplateWriter = %q, want %q", got, want)
+	}
+}
+
+func TestTemplateFuncs(t *testing.T) {
+	date := time.Date(2024, 3, 4, 5, 6, 7, 0, time.UTC)
+	tests := []struct {
+		name string
+		got  func() (string, error)
+		want string
+	}{
+		{name: "number", got: func() (string, error) { return templateNumber(2, 1234567.891) }, want: "1,234,567.89"},
+		{name: "numberString", got: func() (string, error) { return templateNumber(0, "-1234") }, want: "-1,234"},
+		{name: "numberEmpty", got: func() (string, error) { return templateNumber(0, "") }, want: ""},
+		{name: "dateTime", got: func() (string, error) { return templateDate("2006/01/02", date) }, want: "2024/03/04"},
+		{name: "dateString", got: func() (string, error) { return templateDate("Jan 2 15:04", "2024-03-04 05:06:07") }, want: "Mar 4 05:06"},
+		{name: "json", got: func() (string, error) { return templateJSON(`a"b`) }, want: `"a\"b"`},
+		{name: "xml", got: func() (string, error) { return templateXML("<a&b>") }, want: "&lt;a&amp;b&gt;"},
+		{name: "sql", got: func() (string, error) { return templateSQL("it's"), nil }, want: "'it''s'"},
+		{name: "shell", got: func() (string, error) { return templateShell("it's"), nil }, want: `'it'\''s'`},
+		{name: "csv", got: func() (string, error) { return templateCSV(`a "b"`), nil }, want: `"a ""b"""`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.got()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != tt.want {
+				t.Errorf("%s = %q, want %q", tt.name, got, tt.want)
+			}
+		})
+	}
+	if _, err := templateNumber(0, "abc"); err == nil {
+		t.Error("templateNumber() error = nil, want error")
+	}
+	if _, err := templateDate("2006", "abc"); err == nil {
+		t.Error("templateDate() error = nil, want error")
+	}
+}
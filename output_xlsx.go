@@ -0,0 +1,253 @@
+package trdsql
+
+import (
+	"bufio"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/mattn/go-runewidth"
+	"github.com/xuri/excelize/v2"
+)
+
+// xlsxMaxColWidth is the maximum width of the column.
+const xlsxMaxColWidth = 80
+
+// XLSXWriter writes query results as an Excel XLSX workbook.
+// Each result of multiple queries is written to its own sheet,
+// and the workbook is output when Finish is called.
+type XLSXWriter struct {
+	writer    *bufio.Writer
+	file      *excelize.File
+	sheet     string
+	outNULL   string
+	columns   []string
+	kinds     []xlsxKind
+	widths    []int
+	header    int
+	dateStyle int
+	timeStyle int
+	rowNum    int
+	sheetNum  int
+	needNULL  bool
+	err       error
+}
+
+// xlsxKind is the kind of cell value.
+type xlsxKind int
+
+const (
+	xlsxString xlsxKind = iota
+	xlsxInt
+	xlsxFloat
+	xlsxBool
+	xlsxDate
+	xlsxTimestamp
+	xlsxValue // determined by the value.
+)
+
+// NewXLSXWriter returns XLSXWriter.
+func NewXLSXWriter(writeOpts *WriteOpts) *XLSXWriter {
+	w := &XLSXWriter{}
+	w.writer = bufio.NewWriter(writeOpts.OutStream)
+	w.needNULL = writeOpts.OutNeedNULL
+	w.outNULL = writeOpts.OutNULL
+	w.file = excelize.NewFile()
+
+	var err error
+	if w.header, err = w.file.NewStyle(&excelize.Style{Font: &excelize.Font{Bold: true}}); err != nil {
+		w.err = err
+	}
+	dateFmt := "yyyy-mm-dd"
+	if w.dateStyle, err = w.file.NewStyle(&excelize.Style{CustomNumFmt: &dateFmt}); err != nil {
+		w.err = err
+	}
+	timeFmt := "yyyy-mm-dd hh:mm:ss"
+	if w.timeStyle, err = w.file.NewStyle(&excelize.Style{CustomNumFmt: &timeFmt}); err != nil {
+		w.err = err
+	}
+	return w
+}
+
+// xlsxColumnKind returns the kind of cell from the database type name.
+func xlsxColumnKind(dbType string) xlsxKind {
+	switch dbTypeKind(dbType) {
+	case typeInt, typeBigint:
+		return xlsxInt
+	case typeFloat, typeDecimal:
+		return xlsxFloat
+	case typeBool:
+		return xlsxBool
+	case typeDate:
+		return xlsxDate
+	case typeTimestamp:
+		return xlsxTimestamp
+	case typeUnknown:
+		return xlsxValue
+	default:
+		return xlsxString
+	}
+}
+
+// PreWrite adds a sheet and writes the header row.
+func (w *XLSXWriter) PreWrite(columns []string, types []string) error {
+	if w.err != nil {
+		return w.err
+	}
+	w.sheetNum++
+	w.sheet = "Sheet" + strconv.Itoa(w.sheetNum)
+	if w.sheetNum > 1 {
+		if _, err := w.file.NewSheet(w.sheet); err != nil {
+			return err
+		}
+	}
+	w.columns = columns
+	w.kinds = make([]xlsxKind, len(columns))
+	w.widths = make([]int, len(columns))
+	header := make([]any, len(columns))
+	for i, col := range columns {
+		w.kinds[i] = xlsxValue
+		if i < len(types) {
+			w.kinds[i] = xlsxColumnKind(types[i])
+		}
+		w.widths[i] = runewidth.StringWidth(col)
+		header[i] = col
+	}
+	w.rowNum = 1
+	if err := w.file.SetSheetRow(w.sheet, "A1", &header); err != nil {
+		return err
+	}
+	if len(columns) == 0 {
+		return nil
+	}
+	end, err := excelize.CoordinatesToCellName(len(columns), 1)
+	if err != nil {
+		return err
+	}
+	return w.file.SetCellStyle(w.sheet, "A1", end, w.header)
+}
+
+// WriteRow writes a row with the cells typed from the column types.
+func (w *XLSXWriter) WriteRow(values []any, columns []string) error {
+	w.rowNum++
+	for i, v := range values {
+		cell, err := excelize.CoordinatesToCellName(i+1, w.rowNum)
+		if err != nil {
+			return err
+		}
+		if v == nil {
+			if w.needNULL {
+				if err := w.file.SetCellStr(w.sheet, cell, w.outNULL); err != nil {
+					return err
+				}
+				w.setWidth(i, runewidth.StringWidth(w.outNULL))
+			}
+			continue
+		}
+		if err := w.setCell(i, cell, v); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (w *XLSXWriter) setCell(i int, cell string, v any) error {
+	kind := w.kinds[i]
+	if kind == xlsxValue {
+		kind = xlsxValueKind(v)
+	}
+	switch kind {
+	case xlsxInt:
+		if n, err := strconv.ParseInt(ValString(v), 10, 64); err == nil {
+			w.setWidth(i, len(strconv.FormatInt(n, 10)))
+			return w.file.SetCellInt(w.sheet, cell, n)
+		}
+	case xlsxFloat:
+		if f, err := strconv.ParseFloat(ValString(v), 64); err == nil {
+			w.setWidth(i, len(strconv.FormatFloat(f, 'f', -1, 64)))
+			return w.file.SetCellFloat(w.sheet, cell, f, -1, 64)
+		}
+	case xlsxBool:
+		if b, ok := v.(bool); ok {
+			w.setWidth(i, 5)
+			return w.file.SetCellBool(w.sheet, cell, b)
+		}
+		if b, err := strconv.ParseBool(ValString(v)); err == nil {
+			w.setWidth(i, 5)
+			return w.file.SetCellBool(w.sheet, cell, b)
+		}
+	case xlsxDate, xlsxTimestamp:
+		if t, err := valueTime(v); err == nil {
+			return w.setTime(i, cell, t, kind)
+		}
+	}
+	str := ValString(v)
+	w.setWidth(i, runewidth.StringWidth(str))
+	return w.file.SetCellStr(w.sheet, cell, str)
+}
+
+// xlsxValueKind returns the kind of cell from the value.
+func xlsxValueKind(v any) xlsxKind {
+	switch v.(type) {
+	case int, int32, int64:
+		return xlsxInt
+	case float32, float64:
+		return xlsxFloat
+	case bool:
+		return xlsxBool
+	case time.Time:
+		return xlsxTimestamp
+	default:
+		return xlsxString
+	}
+}
+
+func (w *XLSXWriter) setTime(i int, cell string, t time.Time, kind xlsxKind) error {
+	// Excel has no time zone, so the wall clock time of the value is written.
+	t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour(), t.Minute(), t.Second(), t.Nanosecond(), time.UTC)
+	if err := w.file.SetCellValue(w.sheet, cell, t); err != nil {
+		return err
+	}
+	style := w.timeStyle
+	width := len("2006-01-02 15:04:05")
+	if kind == xlsxDate {
+		style = w.dateStyle
+		width = len("2006-01-02")
+	}
+	w.setWidth(i, width)
+	return w.file.SetCellStyle(w.sheet, cell, cell, style)
+}
+
+func (w *XLSXWriter) setWidth(i int, width int) {
+	if width > w.widths[i] {
+		w.widths[i] = width
+	}
+}
+
+// PostWrite sets the column widths of the sheet.
+func (w *XLSXWriter) PostWrite() error {
+	for i, width := range w.widths {
+		col, err := excelize.ColumnNumberToName(i + 1)
+		if err != nil {
+			return err
+		}
+		width = min(width+2, xlsxMaxColWidth)
+		if err := w.file.SetColWidth(w.sheet, col, col, float64(width)); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Finish writes the workbook.
+func (w *XLSXWriter) Finish() error {
+	defer func() {
+		if err := w.file.Close(); err != nil {
+			debug.Printf("xlsx: %s", err)
+		}
+	}()
+	if _, err := w.file.WriteTo(w.writer); err != nil {
+		return fmt.Errorf("xlsx: %w", err)
+	}
+	return w.writer.Flush()
+}
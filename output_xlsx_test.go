@@ -0,0 +1,91 @@
+package trdsql
+
+import (
+	"bytes"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestXLSXWriter(t *testing.T) {
+	buf := new(bytes.Buffer)
+	w := NewXLSXWriter(&WriteOpts{OutStream: buf, OutNeedNULL: true, OutNULL: "NULL"})
+	results := []struct {
+		columns []string
+		types   []string
+		rows    [][]any
+	}{
+		{
+			columns: []string{"id", "name", "price", "day", "fresh"},
+			types:   []string{"INTEGER", "TEXT", "REAL", "DATE", "BOOL"},
+			rows: [][]any{
+				{int64(1), "Orange", 50.5, time.Date(2022, 1, 8, 0, 0, 0, 0, time.UTC), true},
+				{"2", []byte("Melon"), nil, "2022-01-09", "false"},
+			},
+		},
+		{
+			columns: []string{"c1", "c2"},
+			types:   []string{"", ""},
+			rows: [][]any{
+				{int64(10), "a"},
+			},
+		},
+	}
+	for _, result := range results {
+		if err := w.PreWrite(result.columns, result.types); err != nil {
+			t.Fatal(err)
+		}
+		for _, row := range result.rows {
+			if err := w.WriteRow(row, result.columns); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.PostWrite(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := w.Finish(); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		sheet       string
+		wantNames   []string
+		wantTypes   []string
+		wantPreRead [][]any
+	}{
+		{
+			sheet:     "Sheet1",
+			wantNames: []string{"id", "name", "price", "day", "fresh"},
+			wantTypes: []string{"numeric", "text", "text", "timestamp", "bool"},
+			wantPreRead: [][]any{
+				{"1", "Orange", "50.5", time.Date(2022, 1, 8, 0, 0, 0, 0, time.UTC), true},
+				{"2", "Melon", "NULL", time.Date(2022, 1, 9, 0, 0, 0, 0, time.UTC), false},
+			},
+		},
+		{
+			sheet:       "Sheet2",
+			wantNames:   []string{"c1", "c2"},
+			wantTypes:   []string{"numeric", "text"},
+			wantPreRead: [][]any{{"10", "a"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.sheet, func(t *testing.T) {
+			opts := NewReadOpts(InHeader(true), InPreRead(3), InSelector(tt.sheet))
+			r, err := NewXLSXReader(bytes.NewReader(buf.Bytes()), opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(r.names, tt.wantNames) {
+				t.Errorf("XLSXWriter names = %v, want %v", r.names, tt.wantNames)
+			}
+			if !reflect.DeepEqual(r.types, tt.wantTypes) {
+				t.Errorf("XLSXWriter types = %v, want %v", r.types, tt.wantTypes)
+			}
+			if got := r.PreReadRow(); !reflect.DeepEqual(got, tt.wantPreRead) {
+				t.Errorf("XLSXWriter rows = %v, want %v", got, tt.wantPreRead)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package trdsql
+
+import (
+	"bufio"
+	"encoding/xml"
+	"fmt"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// XMLWriter writes rows as XML.
+// Each row is written as it arrives, so the whole document is not buffered.
+// The output is a single XML document, so multiple results are not supported.
+//
+//	<?xml version="1.0" encoding="UTF-8"?>
+//	<rows>
+//	  <row>
+//	    <id>1</id>
+//	    <name>Orange</name>
+//	  </row>
+//	</rows>
+type XMLWriter struct {
+	writer   *bufio.Writer
+	root     string
+	row      string
+	outNULL  string
+	names    []string
+	attr     bool
+	needNULL bool
+	written  bool
+}
+
+// NewXMLWriter returns a XMLWriter configured with output options.
+func NewXMLWriter(writeOpts *WriteOpts) *XMLWriter {
+	w := &XMLWriter{}
+	w.writer = bufio.NewWriter(writeOpts.OutStream)
+	w.root = xmlName(writeOpts.OutXMLRoot, "rows")
+	w.row = xmlName(writeOpts.OutXMLRow, "row")
+	w.attr = writeOpts.OutXMLAttr
+	w.needNULL = writeOpts.OutNeedNULL
+	w.outNULL = writeOpts.OutNULL
+	return w
+}
+
+// xmlName returns a valid XML name.
+// Characters that cannot be used in the name are replaced with "_".
+// If the name is empty, def is returned.
+func xmlName(name string, def string) string {
+	if name == "" {
+		return def
+	}
+	var b strings.Builder
+	for i, c := range name {
+		switch {
+		case c == '_' || unicode.IsLetter(c):
+		case i > 0 && (c == '-' || c == '.' || unicode.IsDigit(c)):
+		case i == 0 && (c == '-' || c == '.' || unicode.IsDigit(c)):
+			b.WriteRune('_')
+		default:
+			c = '_'
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// PreWrite writes the XML declaration and the start tag of the root element.
+func (w *XMLWriter) PreWrite(columns []string, types []string) error {
+	if w.written {
+		return fmt.Errorf("xml: %w", ErrMultipleResults)
+	}
+	w.written = true
+	w.names = make([]string, len(columns))
+	already := make(map[string]bool)
+	for i, col := range columns {
+		name := xmlName(col, "c"+strconv.Itoa(i+1))
+		for n := 2; already[name]; n++ {
+			name = xmlName(col, "c"+strconv.Itoa(i+1)) + "_" + strconv.Itoa(n)
+		}
+		already[name] = true
+		w.names[i] = name
+	}
+	if _, err := w.writer.WriteString(xml.Header); err != nil {
+		return err
+	}
+	_, err := w.writer.WriteString("<" + w.root + ">\n")
+	return err
+}
+
+// WriteRow is row write to XML.
+// NULL columns are omitted unless OutNeedNULL is set.
+func (w *XMLWriter) WriteRow(values []any, columns []string) error {
+	if w.attr {
+		return w.writeAttr(values)
+	}
+	if _, err := w.writer.WriteString("  <" + w.row + ">\n"); err != nil {
+		return err
+	}
+	for i, col := range values {
+		str, ok := w.value(col)
+		if !ok {
+			continue
+		}
+		if _, err := w.writer.WriteString("    <" + w.names[i] + ">"); err != nil {
+			return err
+		}
+		if err := xml.EscapeText(w.writer, []byte(str)); err != nil {
+			return err
+		}
+		if _, err := w.writer.WriteString("</" + w.names[i] + ">\n"); err != nil {
+			return err
+		}
+	}
+	_, err := w.writer.WriteString("  </" + w.row + ">\n")
+	return err
+}
+
+// writeAttr writes the columns as attributes of the row element.
+func (w *XMLWriter) writeAttr(values []any) error {
+	if _, err := w.writer.WriteString("  <" + w.row); err != nil {
+		return err
+	}
+	for i, col := range values {
+		str, ok := w.value(col)
+		if !ok {
+			continue
+		}
+		if _, err := w.writer.WriteString(" " + w.names[i] + `="`); err != nil {
+			return err
+		}
+		if err := xml.EscapeText(w.writer, []byte(str)); err != nil {
+			return err
+		}
+		if err := w.writer.WriteByte('"'); err != nil {
+			return err
+		}
+	}
+	_, err := w.writer.WriteString("/>\n")
+	return err
+}
+
+// value returns the string of the value.
+// It returns false if the value is NULL and is not replaced.
+func (w *XMLWriter) value(v any) (string, bool) {
+	if v == nil {
+		if !w.needNULL {
+			return "", false
+		}
+		return w.outNULL, true
+	}
+	return ValString(v), true
+}
+
+// PostWrite writes the end tag of the root element.
+func (w *XMLWriter) PostWrite() error {
+	if _, err := w.writer.WriteString("</" + w.root + ">\n"); err != nil {
+		return err
+	}
+	return w.writer.Flush()
+}
@@ -0,0 +1,147 @@
+package trdsql
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+)
+
+func TestXMLWriter(t *testing.T) {
+	tests := []struct {
+		name  string
+		opts  *WriteOpts
+		names []string
+		want  string
+	}{
+		{
+			name:  "element",
+			opts:  &WriteOpts{},
+			names: []string{"id", "name", "price"},
+			want: `<?xml version="1.0" encoding="UTF-8"?>
+<rows>
+  <row>
+    <id>1</id>
+    <name>Apple &amp; &lt;Pie&gt;</name>
+  </row>
+  <row>
+    <id>2</id>
+    <name>Melon</name>
+    <price>500</price>
+  </row>
+</rows>
+`,
+		},
+		{
+			name:  "attr",
+			opts:  &WriteOpts{OutXMLRoot: "catalog", OutXMLRow: "item", OutXMLAttr: true},
+			names: []string{"id", "name", "price"},
+			want: `<?xml version="1.0" encoding="UTF-8"?>
+<catalog>
+  <item id="1" name="Apple &amp; &lt;Pie&gt;"/>
+  <item id="2" name="Melon" price="500"/>
+</catalog>
+`,
+		},
+		{
+			name:  "needNULL",
+			opts:  &WriteOpts{OutNeedNULL: true, OutNULL: "NULL"},
+			names: []string{"id", "name", "price"},
+			want: `<?xml version="1.0" encoding="UTF-8"?>
+<rows>
+  <row>
+    <id>1</id>
+    <name>Apple &amp; &lt;Pie&gt;</name>
+    <price>NULL</price>
+  </row>
+  <row>
+    <id>2</id>
+    <name>Melon</name>
+    <price>500</price>
+  </row>
+</rows>
+`,
+		},
+		{
+			name:  "invalidNames",
+			opts:  &WriteOpts{OutXMLAttr: true},
+			names: []string{"1id", "first name", "first name"},
+			want: `<?xml version="1.0" encoding="UTF-8"?>
+<rows>
+  <row _1id="1" first_name="Apple &amp; &lt;Pie&gt;"/>
+  <row _1id="2" first_name="Melon" first_name_2="500"/>
+</rows>
+`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			tt.opts.OutStream = buf
+			w := NewXMLWriter(tt.opts)
+			if err := w.PreWrite(tt.names, []string{"int", "text", "int"}); err != nil {
+				t.Fatal(err)
+			}
+			rows := [][]any{
+				{int64(1), "Apple & <Pie>", nil},
+				{int64(2), "Melon", int64(500)},
+			}
+			for _, row := range rows {
+				if err := w.WriteRow(row, tt.names); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := w.PostWrite(); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("XMLWriter = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestXMLWriterRoundTrip(t *testing.T) {
+	buf := new(bytes.Buffer)
+	w := NewXMLWriter(&WriteOpts{OutStream: buf})
+	columns := []string{"id", "note"}
+	if err := w.PreWrite(columns, []string{"int", "text"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WriteRow([]any{int64(1), "a\tb\nc \"d\""}, columns); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.PostWrite(); err != nil {
+		t.Fatal(err)
+	}
+
+	r, err := NewXMLReader(buf, NewReadOpts())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(r.names, columns) {
+		t.Errorf("XMLReader names = %v, want %v", r.names, columns)
+	}
+	want := [][]any{{"1", "a\tb\nc \"d\""}}
+	if got := r.PreReadRow(); !reflect.DeepEqual(got, want) {
+		t.Errorf("XMLReader rows = %v, want %v", got, want)
+	}
+}
+
+func TestXMLWriterMulti(t *testing.T) {
+	buf := new(bytes.Buffer)
+	w := NewXMLWriter(&WriteOpts{OutStream: buf})
+	columns := []string{"a"}
+	if err := w.PreWrite(columns, []string{"int"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.WriteRow([]any{int64(1)}, columns); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.PostWrite(); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.PreWrite([]string{"b"}, []string{"int"}); !errors.Is(err, ErrMultipleResults) {
+		t.Errorf("XMLWriter.PreWrite() error = %v, want %v", err, ErrMultipleResults)
+	}
+}
@@ -1,6 +1,7 @@
 package trdsql
 
 import (
+	"bufio"
 	"encoding/hex"
 	"unicode/utf8"
 
@@ -8,37 +9,50 @@ import (
 )
 
 // YAMLWriter writes result rows as a YAML sequence of objects.
+// Each row is written as it arrives, so the whole sequence is not buffered.
+// The results of multiple queries are separate documents("---").
 type YAMLWriter struct {
-	writer   *yaml.Encoder
+	writer   *bufio.Writer
 	outNULL  string
-	results  []yaml.MapSlice
+	count    int
+	results  int
 	needNULL bool
 }
 
 // NewYAMLWriter returns a YAMLWriter configured with output options.
 func NewYAMLWriter(writeOpts *WriteOpts) *YAMLWriter {
 	w := &YAMLWriter{}
-	w.writer = yaml.NewEncoder(writeOpts.OutStream)
+	w.writer = bufio.NewWriter(writeOpts.OutStream)
 	w.needNULL = writeOpts.OutNeedNULL
 	w.outNULL = writeOpts.OutNULL
 	return w
 }
 
-// PreWrite is area preparation.
+// PreWrite writes the document separator from the second result.
 func (w *YAMLWriter) PreWrite(columns []string, types []string) error {
-	w.results = make([]yaml.MapSlice, 0)
-	return nil
+	w.count = 0
+	w.results++
+	if w.results == 1 {
+		return nil
+	}
+	_, err := w.writer.WriteString("---\n")
+	return err
 }
 
-// WriteRow is Addition to array.
+// WriteRow writes the row as an item of the sequence.
 func (w *YAMLWriter) WriteRow(values []any, columns []string) error {
 	m := make(yaml.MapSlice, len(values))
 	for i, col := range values {
 		m[i].Key = columns[i]
 		m[i].Value = compatibleYAML(col, w.needNULL, w.outNULL)
 	}
-	w.results = append(w.results, m)
-	return nil
+	b, err := yaml.Marshal([]yaml.MapSlice{m})
+	if err != nil {
+		return err
+	}
+	w.count++
+	_, err = w.writer.Write(b)
+	return err
 }
 
 // CompatibleYAML converts the value to a YAML-compatible value.
@@ -67,7 +81,13 @@ func compatibleYAML(v any, needNULL bool, outNULL string) any {
 	}
 }
 
-// PostWrite is actual output.
+// PostWrite flushes.
+// An empty sequence is written if there are no rows.
 func (w *YAMLWriter) PostWrite() error {
-	return w.writer.Encode(w.results)
+	if w.count == 0 {
+		if _, err := w.writer.WriteString("[]\n"); err != nil {
+			return err
+		}
+	}
+	return w.writer.Flush()
 }
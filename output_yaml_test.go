@@ -0,0 +1,92 @@
+package trdsql
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestYAMLWriter(t *testing.T) {
+	tests := []struct {
+		name string
+		rows [][]any
+		want string
+	}{
+		{
+			name: "rows",
+			rows: [][]any{
+				{1, "Orange", nil},
+				{2, "Melon", `{"a":[1,2]}`},
+			},
+			want: "- id: 1\n" +
+				"  name: Orange\n" +
+				"  note: null\n" +
+				"- id: 2\n" +
+				"  name: Melon\n" +
+				"  note:\n" +
+				"    a:\n" +
+				"    - 1\n" +
+				"    - 2\n",
+		},
+		{
+			name: "empty",
+			rows: nil,
+			want: "[]\n",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := new(bytes.Buffer)
+			w := NewYAMLWriter(&WriteOpts{OutStream: buf})
+			names := []string{"id", "name", "note"}
+			if err := w.PreWrite(names, []string{"int", "text", "text"}); err != nil {
+				t.Fatal(err)
+			}
+			for _, row := range tt.rows {
+				if err := w.WriteRow(row, names); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if err := w.PostWrite(); err != nil {
+				t.Fatal(err)
+			}
+			if got := buf.String(); got != tt.want {
+				t.Errorf("YAMLWriter = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestYAMLWriterMulti(t *testing.T) {
+	buf := new(bytes.Buffer)
+	w := NewYAMLWriter(&WriteOpts{OutStream: buf})
+	results := []struct {
+		column string
+		rows   [][]any
+	}{
+		{column: "a", rows: [][]any{{1}}},
+		{column: "b", rows: nil},
+		{column: "c", rows: [][]any{{3}}},
+	}
+	for _, result := range results {
+		names := []string{result.column}
+		if err := w.PreWrite(names, []string{"int"}); err != nil {
+			t.Fatal(err)
+		}
+		for _, row := range result.rows {
+			if err := w.WriteRow(row, names); err != nil {
+				t.Fatal(err)
+			}
+		}
+		if err := w.PostWrite(); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := "- a: 1\n" +
+		"---\n" +
+		"[]\n" +
+		"---\n" +
+		"- c: 3\n"
+	if got := buf.String(); got != want {
+		t.Errorf("YAMLWriter = %q, want %q", got, want)
+	}
+}
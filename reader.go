@@ -1,6 +1,7 @@
 package trdsql
 
 import (
+	"bytes"
 	"io"
 	"log"
 	"sync"
@@ -8,17 +9,35 @@ import (
 
 // extToFormat is a map of file extensions to formats.
 var extToFormat map[string]Format = map[string]Format{
-	"CSV":   CSV,
-	"LTSV":  LTSV,
-	"JSON":  JSON,
-	"JSONL": JSON,
-	"YAML":  YAML,
-	"YML":   YAML,
-	"TBLN":  TBLN,
-	"TSV":   TSV,
-	"PSV":   PSV,
-	"WIDTH": WIDTH,
-	"TEXT":  TEXT,
+	"CSV":     CSV,
+	"LTSV":    LTSV,
+	"JSON":    JSON,
+	"JSONL":   JSON,
+	"YAML":    YAML,
+	"YML":     YAML,
+	"TBLN":    TBLN,
+	"TSV":     TSV,
+	"PSV":     PSV,
+	"WIDTH":   WIDTH,
+	"TEXT":    TEXT,
+	"PARQUET": PARQUET,
+	"XLSX":    XLSX,
+	"ARROW":   ARROW,
+	"FEATHER": ARROW,
+	"AVRO":    AVRO,
+	"XML":     XML,
+	"MD":      MD,
+	"AT":      AT,
+	"VF":      VF,
+	"DB":      SQLITE,
+	"SQLITE":  SQLITE,
+	"SQLITE3": SQLITE,
+	"LOGFMT":  LOGFMT,
+	"TOML":    TOML,
+	"INI":     INI,
+	"ENV":     ENV,
+	"HTML":    HTML,
+	"HTM":     HTML,
 }
 
 // ReaderFunc is a function that creates a new Reader.
@@ -48,11 +67,79 @@ var readerFuncs = map[Format]ReaderFunc{
 		return NewPSVReader(reader, opts)
 	},
 	WIDTH: func(reader io.Reader, opts *ReadOpts) (Reader, error) {
+		if opts.InWidths != "" {
+			return NewFWReader(reader, opts)
+		}
 		return NewGWReader(reader, opts)
 	},
 	TEXT: func(reader io.Reader, opts *ReadOpts) (Reader, error) {
 		return NewTextReader(reader, opts)
 	},
+	PARQUET: func(reader io.Reader, opts *ReadOpts) (Reader, error) {
+		return NewParquetReader(reader, opts)
+	},
+	XLSX: func(reader io.Reader, opts *ReadOpts) (Reader, error) {
+		return NewXLSXReader(reader, opts)
+	},
+	ARROW: func(reader io.Reader, opts *ReadOpts) (Reader, error) {
+		return NewArrowReader(reader, opts)
+	},
+	AVRO: func(reader io.Reader, opts *ReadOpts) (Reader, error) {
+		return NewAvroReader(reader, opts)
+	},
+	XML: func(reader io.Reader, opts *ReadOpts) (Reader, error) {
+		return NewXMLReader(reader, opts)
+	},
+	MD: func(reader io.Reader, opts *ReadOpts) (Reader, error) {
+		return NewTWReader(reader, opts)
+	},
+	AT: func(reader io.Reader, opts *ReadOpts) (Reader, error) {
+		return NewTWReader(reader, opts)
+	},
+	VF: func(reader io.Reader, opts *ReadOpts) (Reader, error) {
+		return NewVFReader(reader, opts)
+	},
+	SQLITE: func(reader io.Reader, opts *ReadOpts) (Reader, error) {
+		return NewSQLiteReader(reader, opts)
+	},
+	REGEX: func(reader io.Reader, opts *ReadOpts) (Reader, error) {
+		return NewRegexReader(reader, opts)
+	},
+	ACCESSLOG: func(reader io.Reader, opts *ReadOpts) (Reader, error) {
+		return NewAccessLogReader(reader, opts)
+	},
+	W3C: func(reader io.Reader, opts *ReadOpts) (Reader, error) {
+		return NewW3CReader(reader, opts)
+	},
+	SYSLOG: func(reader io.Reader, opts *ReadOpts) (Reader, error) {
+		return NewSyslogReader(reader, opts)
+	},
+	LOGFMT: func(reader io.Reader, opts *ReadOpts) (Reader, error) {
+		return NewLogfmtReader(reader, opts)
+	},
+	TOML: func(reader io.Reader, opts *ReadOpts) (Reader, error) {
+		return NewTOMLReader(reader, opts)
+	},
+	INI: func(reader io.Reader, opts *ReadOpts) (Reader, error) {
+		return NewINIReader(reader, opts)
+	},
+	ENV: func(reader io.Reader, opts *ReadOpts) (Reader, error) {
+		return NewENVReader(reader, opts)
+	},
+	HTML: func(reader io.Reader, opts *ReadOpts) (Reader, error) {
+		return NewHTMLReader(reader, opts)
+	},
+}
+
+// selectorFormats is a set of formats that use the part after "::"
+// of the file name as InSelector instead of a jq expression.
+var selectorFormats = map[Format]bool{
+	XLSX:   true,
+	XML:    true,
+	SQLITE: true,
+	TOML:   true,
+	INI:    true,
+	HTML:   true,
 }
 
 var (
@@ -95,8 +182,22 @@ type ReadOpts struct {
 	// InJQuery is a jq expression.
 	InJQuery string
 
+	// InSelector selects a part of the file.
+	// For example, the sheet name or index of XLSX, the element path of XML.
+	// It can also be specified after "::" of the file name.
+	InSelector string
+
+	// InRegex is a regular expression with named groups(Use only REGEX).
+	// Grok-style patterns such as %{IPORHOST:client} can be used.
+	InRegex string
+
+	// InWidths is the column positions of the fixed-width file(Use only WIDTH).
+	// e.g. "1-8,9-20,21-" or "id:8,name:12,rest:21-".
+	// If it is empty, the widths are guessed.
+	InWidths string
+
 	// InFormat is read format.
-	// The supported format is CSV/LTSV/JSON/TBLN.
+	// The supported format is CSV/LTSV/JSON/TBLN/PARQUET/XLSX/ARROW/AVRO/XML/MD/AT/VF/SQLITE/REGEX/ACCESSLOG/W3C/SYSLOG/LOGFMT/TOML/INI/ENV/HTML.
 	InFormat   Format
 	realFormat Format
 
@@ -124,6 +225,9 @@ type ReadOpts struct {
 
 	// InRowNumber is row number.
 	InRowNumber bool
+
+	// InRegexReject is true, unmatched lines are stored in the _reject column(Use only REGEX).
+	InRegexReject bool
 }
 
 // NewReadOpts Returns ReadOpts.
@@ -177,6 +281,34 @@ func InJQ(p string) ReadOpt {
 	}
 }
 
+// InRegex is a regular expression with named groups.
+func InRegex(p string) ReadOpt {
+	return func(args *ReadOpts) {
+		args.InRegex = p
+	}
+}
+
+// InRegexReject sets a flag to store unmatched lines in the _reject column.
+func InRegexReject(b bool) ReadOpt {
+	return func(args *ReadOpts) {
+		args.InRegexReject = b
+	}
+}
+
+// InWidths is the column positions of the fixed-width file.
+func InWidths(w string) ReadOpt {
+	return func(args *ReadOpts) {
+		args.InWidths = w
+	}
+}
+
+// InSelector selects a part of the file(e.g. sheet of XLSX).
+func InSelector(s string) ReadOpt {
+	return func(args *ReadOpts) {
+		args.InSelector = s
+	}
+}
+
 // InSkip is number of lines to skip.
 func InSkip(s int) ReadOpt {
 	return func(args *ReadOpts) {
@@ -250,3 +382,25 @@ func skipRead(r Reader, skipNum int) {
 		debug.Printf("Skip row:%s\n", row)
 	}
 }
+
+// readerAtSeeker is a reader that supports random access.
+type readerAtSeeker interface {
+	io.Reader
+	io.ReaderAt
+	io.Seeker
+}
+
+// randomAccessReader returns a reader that supports random access.
+// Formats that store metadata at the end of the file (Parquet, XLSX, etc.)
+// require random access. Files are used as they are,
+// other readers (stdin, compressed files) are read into memory.
+func randomAccessReader(reader io.Reader) (readerAtSeeker, error) {
+	if r, ok := reader.(readerAtSeeker); ok {
+		return r, nil
+	}
+	b, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, err
+	}
+	return bytes.NewReader(b), nil
+}
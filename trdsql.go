@@ -86,15 +86,15 @@ const (
 	// Multiple characters can be selected as delimiter.
 	RAW
 
-	// export
+	// import/export
 	// MarkDown format.
 	MD
 
-	// export
+	// import/export
 	// ASCII Table format.
 	AT
 
-	// export
+	// import/export
 	// Vertical format.
 	VF
 
@@ -113,6 +113,94 @@ const (
 	// import
 	// Pipe-Separated Values format. Format using go standard CSV library.
 	PSV
+
+	// import/export
+	// Apache Parquet format.
+	PARQUET
+
+	// import/export
+	// Excel XLSX format.
+	XLSX
+
+	// import/export
+	// Apache Arrow IPC format (Feather V2).
+	ARROW
+
+	// import/export
+	// Apache Avro Object Container File format.
+	AVRO
+
+	// import/export
+	// XML format.
+	XML
+
+	// import
+	// SQLite database file.
+	SQLITE
+
+	// import
+	// Lines parsed by a regular expression with named groups.
+	REGEX
+
+	// import
+	// Apache/Nginx access log (common and combined format).
+	ACCESSLOG
+
+	// import
+	// W3C Extended Log File Format.
+	W3C
+
+	// import
+	// Syslog (RFC 3164 and RFC 5424).
+	SYSLOG
+
+	// import/export
+	// logfmt (space-separated key=value pairs).
+	LOGFMT
+
+	// export
+	// Fixed-width records.
+	FIXED
+
+	// import
+	// TOML.
+	TOML
+
+	// import
+	// INI.
+	INI
+
+	// import
+	// dotenv(.env).
+	ENV
+
+	// import/export
+	// HTML table.
+	HTML
+
+	// export
+	// SQL INSERT statements.
+	SQL
+
+	// export
+	// AsciiDoc table.
+	ADOC
+
+	// export
+	// reStructuredText table.
+	RST
+
+	// export
+	// LaTeX tabular.
+	LATEX
+
+	// export
+	// Org-mode table.
+	ORG
+
+	// export
+	// Go text/template.
+	TEMPLATE
 )
 
 // String returns the string representation of the Format.
@@ -146,6 +234,50 @@ func (f Format) String() string {
 		return "PSV"
 	case YAML:
 		return "YAML"
+	case PARQUET:
+		return "PARQUET"
+	case XLSX:
+		return "XLSX"
+	case ARROW:
+		return "ARROW"
+	case AVRO:
+		return "AVRO"
+	case XML:
+		return "XML"
+	case SQLITE:
+		return "SQLITE"
+	case REGEX:
+		return "REGEX"
+	case ACCESSLOG:
+		return "ACCESSLOG"
+	case W3C:
+		return "W3C"
+	case SYSLOG:
+		return "SYSLOG"
+	case LOGFMT:
+		return "LOGFMT"
+	case FIXED:
+		return "FIXED"
+	case TOML:
+		return "TOML"
+	case INI:
+		return "INI"
+	case ENV:
+		return "ENV"
+	case HTML:
+		return "HTML"
+	case SQL:
+		return "SQL"
+	case ADOC:
+		return "ADOC"
+	case RST:
+		return "RST"
+	case LATEX:
+		return "LATEX"
+	case ORG:
+		return "ORG"
+	case TEMPLATE:
+		return "TEMPLATE"
 	default:
 		return "Unknown"
 	}
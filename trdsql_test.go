@@ -612,6 +612,13 @@ func TestOutFormatRun(t *testing.T) {
 		{format: TBLN, result: "tbln"},
 		{format: JSONL, result: "jsonl"},
 		{format: YAML, result: "yaml"},
+		{format: LOGFMT, result: "logfmt"},
+		{format: HTML, result: "html"},
+		{format: SQL, result: "sql"},
+		{format: ADOC, result: "adoc"},
+		{format: RST, result: "rst"},
+		{format: LATEX, result: "latex"},
+		{format: ORG, result: "org"},
 	}
 	sqlQuery := "SELECT * FROM " + filepath.Join(dataDir, "test.csv")
 	for _, c := range testFormat {
@@ -704,6 +711,24 @@ func TestTRDSQL_FileExec(t *testing.T) {
 		{fileName: "test_indefinite.json", want: 3, wantErr: false},
 		{fileName: "test_indefinite.ltsv", want: 3, wantErr: false},
 		{fileName: "testcsv", want: 3, wantErr: false},
+		{fileName: "test.parquet", want: 3, wantErr: false},
+		{fileName: "test.xlsx", want: 4, wantErr: false},
+		{fileName: "test.xlsx::note", want: 2, wantErr: false},
+		{fileName: "test.arrow", want: 3, wantErr: false},
+		{fileName: "test.avro", want: 3, wantErr: false},
+		{fileName: "test.xml", want: 3, wantErr: false},
+		{fileName: "test.xml::tag", want: 2, wantErr: false},
+		{fileName: "test.md", want: 3, wantErr: false},
+		{fileName: "test.vf", want: 3, wantErr: false},
+		{fileName: "test.db", want: 3, wantErr: false},
+		{fileName: "test.db::note", want: 2, wantErr: false},
+		{fileName: "test.logfmt", want: 3, wantErr: false},
+		{fileName: "test.toml", want: 17, wantErr: false},
+		{fileName: "test.toml::products", want: 2, wantErr: false},
+		{fileName: "test.ini", want: 6, wantErr: false},
+		{fileName: "test.env", want: 4, wantErr: false},
+		{fileName: "test.html", want: 3, wantErr: false},
+		{fileName: "test.html::2", want: 3, wantErr: false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.fileName, func(t *testing.T) {
@@ -786,6 +811,31 @@ func TestFormat_String(t *testing.T) {
 			f:    JSONL,
 			want: "JSONL",
 		},
+		{
+			name: "PARQUET",
+			f:    PARQUET,
+			want: "PARQUET",
+		},
+		{
+			name: "XLSX",
+			f:    XLSX,
+			want: "XLSX",
+		},
+		{
+			name: "ARROW",
+			f:    ARROW,
+			want: "ARROW",
+		},
+		{
+			name: "AVRO",
+			f:    AVRO,
+			want: "AVRO",
+		},
+		{
+			name: "XML",
+			f:    XML,
+			want: "XML",
+		},
 		{
 			name: "Unknown",
 			f:    99,
@@ -0,0 +1,61 @@
+package trdsql
+
+import "strings"
+
+// typeKind is the kind of the database type.
+type typeKind int
+
+const (
+	// typeUnknown is the kind of the empty type name
+	// (e.g. the result of an expression in SQLite).
+	typeUnknown typeKind = iota
+	typeText
+	typeInt
+	typeBigint
+	typeFloat
+	typeDecimal
+	typeBool
+	typeDate
+	typeTime
+	typeTimestamp
+	typeBinary
+)
+
+// dbTypeKind returns the kind of the database type name.
+// The type names returned by the database drivers (SQLite, PostgreSQL and MySQL)
+// are classified here so that the writers agree on them.
+func dbTypeKind(dbType string) typeKind {
+	switch strings.ToLower(dbType) {
+	case "":
+		return typeUnknown
+	case "smallint", "integer", "int", "int2", "int4", "tinyint", "mediumint", "smallserial", "serial":
+		return typeInt
+	case "bigint", "int8", "bigserial", "unsigned int", "unsigned bigint":
+		return typeBigint
+	case "real", "float", "float4", "float8", "double", "double precision":
+		return typeFloat
+	case "decimal", "numeric":
+		return typeDecimal
+	case "bool", "boolean":
+		return typeBool
+	case "date":
+		return typeDate
+	case "time":
+		return typeTime
+	case "timestamp", "timestamptz", "datetime":
+		return typeTimestamp
+	case "blob", "bytea", "binary", "varbinary":
+		return typeBinary
+	default:
+		return typeText
+	}
+}
+
+// isNumericType returns true if the database type is numeric.
+func isNumericType(dbType string) bool {
+	switch dbTypeKind(dbType) {
+	case typeInt, typeBigint, typeFloat, typeDecimal:
+		return true
+	}
+	return false
+}
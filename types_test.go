@@ -0,0 +1,55 @@
+package trdsql
+
+import "testing"
+
+func Test_dbTypeKind(t *testing.T) {
+	tests := []struct {
+		dbType string
+		want   typeKind
+	}{
+		{dbType: "", want: typeUnknown},
+		{dbType: "text", want: typeText},
+		{dbType: "VARCHAR", want: typeText},
+		{dbType: "INT4", want: typeInt},
+		{dbType: "serial", want: typeInt},
+		{dbType: "bigint", want: typeBigint},
+		{dbType: "UNSIGNED BIGINT", want: typeBigint},
+		{dbType: "double precision", want: typeFloat},
+		{dbType: "NUMERIC", want: typeDecimal},
+		{dbType: "boolean", want: typeBool},
+		{dbType: "date", want: typeDate},
+		{dbType: "time", want: typeTime},
+		{dbType: "DATETIME", want: typeTimestamp},
+		{dbType: "bytea", want: typeBinary},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dbType, func(t *testing.T) {
+			if got := dbTypeKind(tt.dbType); got != tt.want {
+				t.Errorf("dbTypeKind(%q) = %v, want %v", tt.dbType, got, tt.want)
+			}
+		})
+	}
+}
+
+func Test_isNumericType(t *testing.T) {
+	tests := []struct {
+		dbType string
+		want   bool
+	}{
+		{dbType: "int", want: true},
+		{dbType: "BIGSERIAL", want: true},
+		{dbType: "unsigned int", want: true},
+		{dbType: "double precision", want: true},
+		{dbType: "decimal", want: true},
+		{dbType: "text", want: false},
+		{dbType: "date", want: false},
+		{dbType: "", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.dbType, func(t *testing.T) {
+			if got := isNumericType(tt.dbType); got != tt.want {
+				t.Errorf("isNumericType(%q) = %v, want %v", tt.dbType, got, tt.want)
+			}
+		})
+	}
+}
@@ -1,24 +1,47 @@
 package trdsql
 
 import (
+	"errors"
 	"io"
 	"os"
 )
 
+var (
+	// ErrUnknownCodec is returned if the compression codec is unknown.
+	ErrUnknownCodec = errors.New("unknown codec")
+	// ErrMultipleResults is returned if the format cannot write multiple results.
+	ErrMultipleResults = errors.New("multiple results are not supported")
+)
+
 // extToOutFormat is a map of file extensions to formats.
 var extToOutFormat = map[string]Format{
-	"CSV":   CSV,
-	"LTSV":  LTSV,
-	"JSON":  JSON,
-	"JSONL": JSONL,
-	"TBLN":  TBLN,
-	"RAW":   RAW,
-	"TSV":   TSV,
-	"MD":    MD,
-	"AT":    AT,
-	"VF":    VF,
-	"YAML":  YAML,
-	"YML":   YAML,
+	"CSV":      CSV,
+	"LTSV":     LTSV,
+	"JSON":     JSON,
+	"JSONL":    JSONL,
+	"TBLN":     TBLN,
+	"RAW":      RAW,
+	"TSV":      TSV,
+	"MD":       MD,
+	"AT":       AT,
+	"VF":       VF,
+	"YAML":     YAML,
+	"YML":      YAML,
+	"PARQUET":  PARQUET,
+	"XLSX":     XLSX,
+	"ARROW":    ARROW,
+	"FEATHER":  ARROW,
+	"AVRO":     AVRO,
+	"XML":      XML,
+	"LOGFMT":   LOGFMT,
+	"HTML":     HTML,
+	"HTM":      HTML,
+	"SQL":      SQL,
+	"ADOC":     ADOC,
+	"ASCIIDOC": ADOC,
+	"RST":      RST,
+	"TEX":      LATEX,
+	"ORG":      ORG,
 }
 
 // Writer is an interface that wraps the Write method that writes from the database to a file.
@@ -33,6 +56,13 @@ type Writer interface {
 	PostWrite() error
 }
 
+// Finisher is an optional interface implemented by writers
+// that output all query results together (e.g. XLSX writes one workbook).
+// Finish is called from Export once after all queries have been written.
+type Finisher interface {
+	Finish() error
+}
+
 // WriteOpts represents options that determine the behavior of the writer.
 type WriteOpts struct {
 	// OutStream is the output destination.
@@ -44,6 +74,13 @@ type WriteOpts struct {
 	OutDelimiter string
 	// OutQuote is the output quote character (Use only CSV).
 	OutQuote string
+	// OutStreamRows is the number of rows to compute the column widths,
+	// and the rows are written as they arrive(Use only AT and MD).
+	// If it is 0, all rows are buffered.
+	OutStreamRows int
+	// OutMaxWidth is the maximum width of the columns(Use only AT and MD).
+	// The columns are this width if OutStreamRows is 0.
+	OutMaxWidth int
 	// OutNeedNULL is true, replace NULL with OutNULL.
 	OutNULL string
 	// OutFormat is the writing format.
@@ -60,6 +97,47 @@ type WriteOpts struct {
 	OutNeedNULL bool
 	// OutJSONToYAML is true, convert JSON to YAML(Use only YAML).
 	OutJSONToYAML bool
+	// OutCodec is the compression codec inside the file(Use only PARQUET, ARROW and AVRO).
+	OutCodec string
+	// OutXMLRoot is the name of the root element(Use only XML).
+	OutXMLRoot string
+	// OutXMLRow is the name of the row element(Use only XML).
+	OutXMLRow string
+	// OutXMLAttr is true, columns are output as attributes of the row element(Use only XML).
+	OutXMLAttr bool
+	// OutFixedWidths is the column widths(Use only FIXED).
+	// e.g. "8,12R,*". If it is empty, the widths are computed from the data.
+	OutFixedWidths string
+	// OutFixedAlign is the alignment of the columns(Use only FIXED).
+	// left, right or auto(numeric columns are right).
+	OutFixedAlign string
+	// OutFixedPad is the padding character(Use only FIXED).
+	OutFixedPad string
+	// OutFixedOverflow is the policy for values that exceed the width(Use only FIXED).
+	// auto(truncate text and error for numeric values), truncate or error.
+	OutFixedOverflow string
+	// OutHTMLDocument is true, output a full HTML document instead of a <table>(Use only HTML).
+	OutHTMLDocument bool
+	// OutSQLTable is the name of the target table(Use only SQL).
+	OutSQLTable string
+	// OutSQLDialect is the dialect of the statements(Use only SQL).
+	// sqlite, mysql or postgres.
+	OutSQLDialect string
+	// OutSQLCreate is true, output CREATE TABLE before INSERT(Use only SQL).
+	OutSQLCreate bool
+	// OutSQLBatch is the number of rows in one INSERT statement(Use only SQL).
+	OutSQLBatch int
+	// OutSQLUpsert is the key columns of the upsert(Use only SQL).
+	// e.g. "id" or "id,name". If it is empty, the statements are plain INSERT.
+	OutSQLUpsert string
+	// OutRSTStyle is the style of the table(Use only RST).
+	// grid or simple.
+	OutRSTStyle string
+	// OutTemplate is the file name of the Go text/template(Use only TEMPLATE).
+	OutTemplate string
+	// OutTemplateString is the Go text/template(Use only TEMPLATE).
+	// It is used if OutTemplate is empty.
+	OutTemplateString string
 }
 
 // WriteOpt is a function to set WriteOpts.
@@ -114,6 +192,20 @@ func OutNoWrap(w bool) WriteOpt {
 	}
 }
 
+// OutStreamRows sets the number of rows to compute the column widths of the streaming table.
+func OutStreamRows(n int) WriteOpt {
+	return func(args *WriteOpts) {
+		args.OutStreamRows = n
+	}
+}
+
+// OutMaxWidth sets the maximum width of the columns of the streaming table.
+func OutMaxWidth(n int) WriteOpt {
+	return func(args *WriteOpts) {
+		args.OutMaxWidth = n
+	}
+}
+
 // OutNeedNULL sets a flag to replace NULL.
 func OutNeedNULL(n bool) WriteOpt {
 	return func(args *WriteOpts) {
@@ -128,6 +220,125 @@ func OutNULL(s string) WriteOpt {
 	}
 }
 
+// OutCodec sets the compression codec inside the file.
+func OutCodec(c string) WriteOpt {
+	return func(args *WriteOpts) {
+		args.OutCodec = c
+	}
+}
+
+// OutXMLRoot sets the name of the root element.
+func OutXMLRoot(s string) WriteOpt {
+	return func(args *WriteOpts) {
+		args.OutXMLRoot = s
+	}
+}
+
+// OutXMLRow sets the name of the row element.
+func OutXMLRow(s string) WriteOpt {
+	return func(args *WriteOpts) {
+		args.OutXMLRow = s
+	}
+}
+
+// OutXMLAttr sets a flag to output columns as attributes.
+func OutXMLAttr(a bool) WriteOpt {
+	return func(args *WriteOpts) {
+		args.OutXMLAttr = a
+	}
+}
+
+// OutFixedWidths sets the column widths of the fixed-width output.
+func OutFixedWidths(s string) WriteOpt {
+	return func(args *WriteOpts) {
+		args.OutFixedWidths = s
+	}
+}
+
+// OutFixedAlign sets the alignment of the fixed-width output.
+func OutFixedAlign(s string) WriteOpt {
+	return func(args *WriteOpts) {
+		args.OutFixedAlign = s
+	}
+}
+
+// OutFixedPad sets the padding character of the fixed-width output.
+func OutFixedPad(s string) WriteOpt {
+	return func(args *WriteOpts) {
+		args.OutFixedPad = s
+	}
+}
+
+// OutFixedOverflow sets the policy for values that exceed the width.
+func OutFixedOverflow(s string) WriteOpt {
+	return func(args *WriteOpts) {
+		args.OutFixedOverflow = s
+	}
+}
+
+// OutHTMLDocument sets a flag to output a full HTML document.
+func OutHTMLDocument(d bool) WriteOpt {
+	return func(args *WriteOpts) {
+		args.OutHTMLDocument = d
+	}
+}
+
+// OutSQLTable sets the name of the target table.
+func OutSQLTable(s string) WriteOpt {
+	return func(args *WriteOpts) {
+		args.OutSQLTable = s
+	}
+}
+
+// OutSQLDialect sets the dialect of the statements.
+func OutSQLDialect(s string) WriteOpt {
+	return func(args *WriteOpts) {
+		args.OutSQLDialect = s
+	}
+}
+
+// OutSQLCreate sets a flag to output CREATE TABLE.
+func OutSQLCreate(c bool) WriteOpt {
+	return func(args *WriteOpts) {
+		args.OutSQLCreate = c
+	}
+}
+
+// OutSQLBatch sets the number of rows in one INSERT statement.
+func OutSQLBatch(n int) WriteOpt {
+	return func(args *WriteOpts) {
+		args.OutSQLBatch = n
+	}
+}
+
+// OutSQLUpsert sets the key columns of the upsert.
+func OutSQLUpsert(s string) WriteOpt {
+	return func(args *WriteOpts) {
+		args.OutSQLUpsert = s
+	}
+}
+
+// OutRSTStyle sets the style of the reStructuredText table.
+func OutRSTStyle(s string) WriteOpt {
+	return func(args *WriteOpts) {
+		args.OutRSTStyle = s
+	}
+}
+
+// OutTemplate sets the file name of the template.
+func OutTemplate(f string) WriteOpt {
+	return func(args *WriteOpts) {
+		args.OutTemplate = f
+	}
+}
+
+// OutTemplateString sets the template.
+func OutTemplateString(s string) WriteOpt {
+	return func(args *WriteOpts) {
+		args.OutTemplateString = s
+	}
+}
+
 // OutStream sets the output destination.
 func OutStream(w io.Writer) WriteOpt {
 	return func(args *WriteOpts) {
@@ -154,16 +365,25 @@ func ErrStream(w io.Writer) WriteOpt {
 //	)
 func NewWriter(options ...WriteOpt) Writer {
 	writeOpts := &WriteOpts{
-		OutFormat:    CSV,
-		OutDelimiter: ",",
-		OutQuote:     "\"",
-		OutAllQuotes: false,
-		OutUseCRLF:   false,
-		OutHeader:    false,
-		OutNeedNULL:  false,
-		OutNULL:      "",
-		OutStream:    os.Stdout,
-		ErrStream:    os.Stderr,
+		OutFormat:        CSV,
+		OutDelimiter:     ",",
+		OutQuote:         "\"",
+		OutAllQuotes:     false,
+		OutUseCRLF:       false,
+		OutHeader:        false,
+		OutNeedNULL:      false,
+		OutNULL:          "",
+		OutXMLRoot:       "rows",
+		OutXMLRow:        "row",
+		OutFixedAlign:    "left",
+		OutFixedPad:      " ",
+		OutFixedOverflow: "auto",
+		OutSQLTable:      "result",
+		OutSQLDialect:    "sqlite",
+		OutSQLBatch:      100,
+		OutRSTStyle:      "grid",
+		OutStream:        os.Stdout,
+		ErrStream:        os.Stderr,
 	}
 	for _, option := range options {
 		option(writeOpts)
@@ -189,6 +409,28 @@ func NewWriter(options ...WriteOpt) Writer {
 		return NewJSONLWriter(writeOpts)
 	case TSV:
 		return NewTSVWriter(writeOpts)
+	case PARQUET:
+		return NewParquetWriter(writeOpts)
+	case XLSX:
+		return NewXLSXWriter(writeOpts)
+	case ARROW:
+		return NewArrowWriter(writeOpts)
+	case AVRO:
+		return NewAvroWriter(writeOpts)
+	case XML:
+		return NewXMLWriter(writeOpts)
+	case LOGFMT:
+		return NewLogfmtWriter(writeOpts)
+	case FIXED:
+		return NewFixedWriter(writeOpts)
+	case HTML:
+		return NewHTMLWriter(writeOpts)
+	case SQL:
+		return NewSQLWriter(writeOpts)
+	case ADOC, RST, LATEX, ORG:
+		return NewDocWriter(writeOpts, writeOpts.OutFormat)
+	case TEMPLATE:
+		return NewTemplateWriter(writeOpts)
 	case CSV:
 		return NewCSVWriter(writeOpts)
 	default: